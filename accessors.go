@@ -0,0 +1,59 @@
+package nullify
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Get returns the value of the field at the dotted path (json or Go field names, e.g. address.city) of the nullified
+// value with pointers dereferenced like Flatten does, false if the field or a struct on the path is not set or the
+// path is unknown
+func Get(nullified any, path string) (any, bool) {
+	v, ok := indirect(reflect.ValueOf(nullified))
+	if !ok || !isNullifiedStruct(v.Type()) {
+		return nil, false
+	}
+
+	field, _, err := fieldByPath(v, path, false, newConfig())
+	if err != nil {
+		return nil, false
+	}
+	return plain(field)
+}
+
+// Has returns true if the field at the dotted path of the nullified value is set, see Get
+func Has(nullified any, path string) bool {
+	_, ok := Get(nullified, path)
+	return ok
+}
+
+// Set sets the field at the dotted path (json or Go field names, e.g. address.city) of the nullified value to value,
+// allocating nil structs on the path. The value is copied like CopyMatching does, e.g. a string is set into a *string
+// field and an original struct into its nullified version, without writing through pointers already held by the
+// nullified value. A nil value unsets the field.
+func Set(nullified any, path string, value any) error {
+	cfg := newConfig()
+	cfg.copyOnWrite = true
+	_, err := setPath(nullified, path, value, cfg)
+	return err
+}
+
+// setPath implements Set, returning the normalized path of json names that was set
+func setPath(nullified any, path string, value any, cfg config) (string, error) {
+	v := reflect.ValueOf(nullified)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return "", fmt.Errorf("nullify: cannot set %s on %T", path, nullified)
+	}
+
+	field, normalized, err := fieldByPath(v, path, true, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if value == nil {
+		field.SetZero()
+	} else if err := copyValue(field, reflect.ValueOf(value), normalized, cfg); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
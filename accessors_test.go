@@ -0,0 +1,174 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	tests := map[string]struct {
+		Path     string
+		Expected any
+		Ok       bool
+	}{
+		"json name":      {Path: "name", Expected: "alice", Ok: true},
+		"go name":        {Path: "Name", Expected: "alice", Ok: true},
+		"nested":         {Path: "address.city", Expected: "Springfield", Ok: true},
+		"struct":         {Path: "address", Expected: map[string]any{"City": "Springfield"}, Ok: true},
+		"embedded":       {Path: "id", Expected: "1", Ok: true},
+		"slice":          {Path: "tags", Expected: []any{"a"}, Ok: true},
+		"not set":        {Path: "address.street", Ok: false},
+		"unknown":        {Path: "unknown", Ok: false},
+		"through a leaf": {Path: "name.first", Ok: false},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			payload := `{"id": "1", "name": "alice", "tags": ["a"], "address": {"city": "Springfield"}}`
+			if err := json.Unmarshal([]byte(payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			value, ok := Get(p, testData.Path)
+			has := Has(p, testData.Path)
+
+			// Assert
+			assert.Equal(t, testData.Expected, value)
+			assert.Equal(t, testData.Ok, ok)
+			assert.Equal(t, testData.Ok, has)
+		})
+	}
+}
+
+func TestGet_NotNullified(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	// Act & Assert
+	assert.False(t, Has(nil, "name"))
+	assert.False(t, Has(&Person{}, "name"))
+}
+
+func TestSet(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"name": "alice", "address": {"city": "Springfield"}}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	errs := []error{
+		Set(p, "address.street", "Main St"),
+		Set(p, "address.city", "Shelbyville"),
+		Set(p, "id", "1"),
+		Set(p, "tags", []string{"a"}),
+		Set(p, "name", nil),
+	}
+
+	// Assert
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, map[string]any{
+		"id":             "1",
+		"tags":           []any{"a"},
+		"address.street": "Main St",
+		"address.city":   "Shelbyville",
+	}, Flatten(p))
+}
+
+func TestSet_Error(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	tests := map[string]struct {
+		Nullified    any
+		Path         string
+		Value        any
+		ErrorMessage string
+	}{
+		"not a pointer": {
+			Nullified:    Person{},
+			Path:         "name",
+			Value:        "alice",
+			ErrorMessage: "nullify: cannot set name on nullify.Person",
+		},
+		"unknown field": {
+			Nullified:    Nullify(Person{}),
+			Path:         "address.zip",
+			Value:        "1234",
+			ErrorMessage: "nullify: address.zip: unknown field",
+		},
+		"wrong type": {
+			Nullified:    Nullify(Person{}),
+			Path:         "name",
+			Value:        42,
+			ErrorMessage: "nullify: name: cannot copy int into string",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := Set(testData.Nullified, testData.Path, testData.Value)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
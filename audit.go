@@ -0,0 +1,138 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// now returns the current time, replaced in tests
+var now = time.Now
+
+// AuditChange is a single changed field of an audit entry
+type AuditChange struct {
+	Path string          `json:"path"` // dotted json path of the field, e.g. address.city
+	From json.RawMessage `json:"from"` // canonical json of the previous value, null if it was not set
+	To   json.RawMessage `json:"to"`   // canonical json of the new value, null if it is cleared
+}
+
+// auditEntry is the json audit record produced by AuditEntry
+type auditEntry struct {
+	Actor     string        `json:"actor"`
+	Timestamp time.Time     `json:"timestamp"`
+	Changes   []AuditChange `json:"changes"`
+	Cleared   []string      `json:"cleared"`
+}
+
+// AuditEntry returns a json audit record of the changes from before to after made by actor:
+//
+//	{"actor": "alice", "timestamp": "...", "changes": [{"path": "name", "from": "a", "to": "b"}], "cleared": []}
+//
+// before and after must be values of the original type: a nil field of a nullified value can either be absent or
+// null, such that a partial patch would report every absent field as cleared. Apply a nullified patch to a copy of
+// the original first (see CopyMatching) and audit the result. Fields are matched by their dotted json path. Nested
+// structs are compared field by field, other values (e.g. slices, maps and time.Time) as a whole by their canonical
+// json (see MarshalCanonical). Changes are sorted by path. Fields that were set before but are nil after are listed
+// as cleared as well.
+func AuditEntry(actor string, before any, after any) ([]byte, error) {
+	for _, value := range []any{before, after} {
+		if v, ok := indirect(reflect.ValueOf(value)); ok && isNullifiedStruct(v.Type()) {
+			return nil, fmt.Errorf("nullify: cannot audit nullified value %T, apply it to the original first", value)
+		}
+	}
+
+	beforeLeaves, afterLeaves := map[string]reflect.Value{}, map[string]reflect.Value{}
+	auditLeaves(beforeLeaves, reflect.ValueOf(before), "")
+	auditLeaves(afterLeaves, reflect.ValueOf(after), "")
+
+	paths := make([]string, 0, len(beforeLeaves)+len(afterLeaves))
+	for path := range beforeLeaves {
+		paths = append(paths, path)
+	}
+	for path := range afterLeaves {
+		if _, ok := beforeLeaves[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	entry := auditEntry{Actor: actor, Timestamp: now().UTC(), Changes: []AuditChange{}, Cleared: []string{}}
+	for _, path := range paths {
+		from, err := auditJson(beforeLeaves, path)
+		if err != nil {
+			return nil, err
+		}
+		to, err := auditJson(afterLeaves, path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(from, to) {
+			continue
+		}
+
+		entry.Changes = append(entry.Changes, AuditChange{Path: path, From: from, To: to})
+		if _, ok := afterLeaves[path]; !ok {
+			entry.Cleared = append(entry.Cleared, path)
+		}
+	}
+	return json.Marshal(entry)
+}
+
+// auditLeaves adds the set leaves of v to leaves by their dotted json path
+func auditLeaves(leaves map[string]reflect.Value, v reflect.Value, path string) {
+	v, ok := indirect(v)
+	if !ok {
+		return
+	}
+	if !isAuditStruct(v.Type()) {
+		leaves[path] = v
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := jsonName(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && tagName == "" {
+			auditLeaves(leaves, v.Field(i), path)
+		} else if path == "" {
+			auditLeaves(leaves, v.Field(i), name)
+		} else {
+			auditLeaves(leaves, v.Field(i), path+"."+name)
+		}
+	}
+}
+
+// isAuditStruct returns true if t is a struct that is compared field by field, i.e. it does not marshal itself
+// (e.g. time.Time)
+func isAuditStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, marshaler := range []reflect.Type{jsonMarshaler, reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()} {
+		if t.Implements(marshaler) || reflect.PointerTo(t).Implements(marshaler) {
+			return false
+		}
+	}
+	return true
+}
+
+// auditJson returns the canonical json of the leaf at path, null if it is not set
+func auditJson(leaves map[string]reflect.Value, path string) (json.RawMessage, error) {
+	leaf, ok := leaves[path]
+	if !ok {
+		return json.RawMessage("null"), nil
+	}
+	if leaf.CanAddr() {
+		leaf = leaf.Addr() // such that methods with pointer receivers are used
+	}
+	return MarshalCanonical(leaf.Interface())
+}
@@ -0,0 +1,106 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAuditEntry(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name     string    `json:"name"`
+		Nickname *string   `json:"nickname"`
+		Tags     []string  `json:"tags"`
+		Birthday time.Time `json:"birthday"`
+		Address  *Address  `json:"address"`
+		Secret   string    `json:"-"`
+	}
+
+	now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	nickname := "al"
+	birthday := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	before := Person{
+		Name:     "alice",
+		Nickname: &nickname,
+		Tags:     []string{"a"},
+		Birthday: birthday,
+		Address:  &Address{Street: "Main St", City: "Springfield"},
+		Secret:   "x",
+	}
+	after := before
+	after.Name = "bob"
+	after.Nickname = nil
+	after.Tags = []string{"a", "b"}
+	after.Address = &Address{Street: "Main St", City: "Shelbyville"}
+	after.Secret = "y"
+
+	// Act
+	entry, err := AuditEntry("admin", before, &after)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"actor": "admin",
+		"timestamp": "2024-01-02T03:04:05Z",
+		"changes": [
+			{"path": "address.city", "from": "Springfield", "to": "Shelbyville"},
+			{"path": "name", "from": "alice", "to": "bob"},
+			{"path": "nickname", "from": "al", "to": null},
+			{"path": "tags", "from": ["a"], "to": ["a", "b"]}
+		],
+		"cleared": ["nickname"]
+	}`, string(entry))
+}
+
+func TestAuditEntry_PartialPatch(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name     string    `json:"name"`
+		Nickname *string   `json:"nickname"`
+		Tags     []string  `json:"tags"`
+		Birthday time.Time `json:"birthday"`
+		Address  *Address  `json:"address"`
+		Secret   string    `json:"-"`
+	}
+
+	before := Person{Name: "alice", Tags: []string{"a"}, Address: &Address{City: "Springfield"}}
+	patch := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"name": "bob", "address": {"street": "Main St"}}`), patch); err != nil {
+		t.Fatal(err)
+	}
+	after := before
+	after.Address = &Address{City: "Springfield"}
+	if err := CopyMatching(patch, &after); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	entry, err := AuditEntry("admin", before, after)
+	_, errNullified := AuditEntry("admin", before, patch)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded struct {
+		Changes []AuditChange `json:"changes"`
+		Cleared []string      `json:"cleared"`
+	}
+	assert.NoError(t, json.Unmarshal(entry, &decoded))
+	assert.Equal(t, []AuditChange{
+		{Path: "address.street", From: json.RawMessage(`""`), To: json.RawMessage(`"Main St"`)},
+		{Path: "name", From: json.RawMessage(`"alice"`), To: json.RawMessage(`"bob"`)},
+	}, decoded.Changes)
+	assert.Equal(t, []string{}, decoded.Cleared)
+	assert.ErrorContains(t, errNullified, "nullify: cannot audit nullified value *struct {")
+}
@@ -0,0 +1,160 @@
+package nullify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// avroRecord is an Avro record schema
+type avroRecord struct {
+	Type   string      `json:"type"`
+	Name   string      `json:"name"`
+	Fields []avroField `json:"fields"`
+}
+
+// avroField is a field of an Avro record schema, Default is the json null literal for nullable fields
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    any             `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// AvroSchema returns the Avro schema (as json) of the nullified version of obj. Nullified fields become
+// `["null", type]` unions with a null default, such that records produced from partial input are valid, while
+// fields left untouched (e.g. tagged `nullify:"-"`) keep their plain type and are required.
+//
+// The top-level record is named after the type of obj, nested records after their parent record and field (e.g.
+// PersonAddress). Fields of embedded structs without a json name are flattened into the record like encoding/json
+// does. Field names follow the json names (or WithTagPriority). Go types map to their Avro counterparts:
+// int64 (and int) to long, smaller integers to int, []byte to bytes, time.Time to a timestamp-millis long, slices
+// and arrays to array and maps with string keys to map. Other types (e.g. interfaces and channels) are an error.
+func AvroSchema(obj any, options ...option) ([]byte, error) {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil, fmt.Errorf("nullify: cannot generate an avro schema for nil")
+	}
+
+	name := typeOf.Name()
+	for t := typeOf; name == "" && t.Kind() == reflect.Pointer; t = t.Elem() {
+		name = t.Elem().Name()
+	}
+	if name == "" {
+		name = "Record"
+	}
+
+	cfg := newConfig(options...)
+	schema, err := avroPlainType(nullifiedType(typeOf, options...).Elem(), name, map[string]bool{}, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(schema)
+}
+
+// avroType returns the Avro schema of t, name is used for records and defined holds the names of the records that
+// are already defined in the schema
+func avroType(t reflect.Type, name string, defined map[string]bool, cfg config) (any, error) {
+	nullable := t.Kind() == reflect.Pointer
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := avroPlainType(t, name, defined, cfg)
+	if err != nil || !nullable {
+		return schema, err
+	}
+	return []any{"null", schema}, nil
+}
+
+// avroPlainType returns the Avro schema of the non-pointer type t
+func avroPlainType(t reflect.Type, name string, defined map[string]bool, cfg config) (any, error) {
+	switch t {
+	case timeType:
+		return map[string]string{"type": "long", "logicalType": "timestamp-millis"}, nil
+	case durationType:
+		return "long", nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int", nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "long", nil
+	case reflect.Float32:
+		return "float", nil
+	case reflect.Float64:
+		return "double", nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes", nil
+		}
+		items, err := avroType(t.Elem(), name, defined, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		key := t.Key()
+		for key.Kind() == reflect.Pointer {
+			key = key.Elem() // nullified map keys are never nil in practice
+		}
+		if key.Kind() != reflect.String {
+			return nil, fmt.Errorf("nullify: avro maps require string keys, %s has %s keys", name, key)
+		}
+		values, err := avroType(t.Elem(), name, defined, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "map", "values": values}, nil
+	case reflect.Struct:
+		return avroStruct(t, name, defined, cfg)
+	}
+	return nil, fmt.Errorf("nullify: no avro type for %s (%s)", name, t)
+}
+
+// avroStruct returns the Avro record schema of the struct type t, or its name if the record is already defined
+func avroStruct(t reflect.Type, name string, defined map[string]bool, cfg config) (any, error) {
+	if t.Name() != "" {
+		name = t.Name()
+	}
+	if defined[name] {
+		return name, nil
+	}
+	defined[name] = true
+
+	record := avroRecord{Type: "record", Name: name, Fields: []avroField{}}
+	for _, field := range encodedFields(t, cfg) {
+		schema, err := avroType(field.field.Type, name+field.field.Name, defined, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		nullable := field.field.Type.Kind() == reflect.Pointer || avroPromotedFromPointer(t, field.index)
+		if nullable && field.field.Type.Kind() != reflect.Pointer {
+			schema = []any{"null", schema}
+		}
+
+		avroField := avroField{Name: field.name, Type: schema}
+		if nullable {
+			avroField.Default = json.RawMessage("null")
+		}
+		record.Fields = append(record.Fields, avroField)
+	}
+	return record, nil
+}
+
+// avroPromotedFromPointer returns whether the field of t at index is promoted from an embedded struct pointer, such
+// that it is missing from the record if the embedded struct is not set
+func avroPromotedFromPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
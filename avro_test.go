@@ -0,0 +1,110 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAvroSchema(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Person struct {
+		ID       int64             `json:"id" nullify:"-"`
+		Name     string            `json:"name"`
+		Age      int32             `json:"age"`
+		Avatar   []byte            `json:"avatar" nullify:"leaf"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Home     Address           `json:"home"`
+		Work     Address           `json:"work"`
+		Secret   string            `json:"-"`
+	}
+
+	expected := `{"type": "record", "name": "Person", "fields": [
+		{"name": "id", "type": "long"},
+		{"name": "name", "type": ["null", "string"], "default": null},
+		{"name": "age", "type": ["null", "int"], "default": null},
+		{"name": "avatar", "type": ["null", "bytes"], "default": null},
+		{"name": "tags", "type": ["null", {"type": "array", "items": ["null", "string"]}], "default": null},
+		{"name": "labels", "type": ["null", {"type": "map", "values": ["null", "string"]}], "default": null},
+		{"name": "birthday", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null},
+		{"name": "home", "type": ["null", {"type": "record", "name": "PersonHome", "fields": [
+			{"name": "street", "type": ["null", "string"], "default": null}
+		]}], "default": null},
+		{"name": "work", "type": ["null", {"type": "record", "name": "PersonWork", "fields": [
+			{"name": "street", "type": ["null", "string"], "default": null}
+		]}], "default": null}
+	]}`
+
+	// Act
+	schema, err := AvroSchema(Person{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(schema))
+}
+
+func TestAvroSchema_Embedded(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID      int64 `json:"id" nullify:"-"`
+		Version int32 `json:"version"`
+	}
+	type Meta struct {
+		Source string `json:"source"`
+	}
+	type Document struct {
+		Base
+		Meta  `json:"meta"`
+		Title string `json:"title"`
+	}
+	expected := `{"type": "record", "name": "Document", "fields": [
+		{"name": "id", "type": ["null", "long"], "default": null},
+		{"name": "version", "type": ["null", "int"], "default": null},
+		{"name": "meta", "type": ["null", {"type": "record", "name": "DocumentMeta", "fields": [
+			{"name": "source", "type": ["null", "string"], "default": null}
+		]}], "default": null},
+		{"name": "title", "type": ["null", "string"], "default": null}
+	]}`
+
+	// Act
+	schema, err := AvroSchema(Document{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(schema))
+}
+
+func TestAvroSchema_Errors(t *testing.T) {
+	tests := map[string]struct {
+		Obj      any
+		Expected string
+	}{
+		"nil": {
+			Obj:      nil,
+			Expected: "nullify: cannot generate an avro schema for nil",
+		},
+		"interface": {
+			Obj:      struct{ Value any }{},
+			Expected: "nullify: no avro type for RecordValue (interface {})",
+		},
+		"map key": {
+			Obj:      struct{ Value map[int]string }{},
+			Expected: "nullify: avro maps require string keys, RecordValue has int keys",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := AvroSchema(testData.Obj)
+
+			// Assert
+			assert.EqualError(t, err, testData.Expected)
+		})
+	}
+}
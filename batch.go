@@ -0,0 +1,65 @@
+package nullify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// Result is the outcome of decoding and validating a single payload
+type Result struct {
+	Presence any   // nullified instance the payload was decoded into, nil if it could not be decoded
+	Err      error // decode error or ValidationErrors, nil if the payload is valid
+}
+
+// ValidateBatch decodes each JSON payload into the nullified type of prototype and validates it with v, spreading
+// the work across GOMAXPROCS workers. The nullified type is computed once and shared by all payloads. The results
+// are returned in the order of the payloads, validation failures are reported as ValidationErrors.
+func ValidateBatch(payloads [][]byte, prototype any, v StructValidator, options ...option) []Result {
+	results := make([]Result, len(payloads))
+	instance := newNullified(prototype, options...)
+	if instance == nil || len(payloads) == 0 {
+		return results
+	}
+	typ := reflect.TypeOf(instance).Elem()
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(payloads) {
+		workers = len(payloads)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = validatePayload(payloads[i], typ, v)
+			}
+		}()
+	}
+
+	for i := range payloads {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results
+}
+
+// validatePayload decodes payload into a new instance of the nullified type typ and validates it with v
+func validatePayload(payload []byte, typ reflect.Type, v StructValidator) Result {
+	presence := reflect.New(typ).Interface()
+	if err := json.Unmarshal(payload, presence); err != nil {
+		return Result{Err: err}
+	}
+
+	if err := v.StructCtx(context.Background(), presence); err != nil {
+		return Result{Presence: presence, Err: validationErrors(presence, err)}
+	}
+	return Result{Presence: presence}
+}
@@ -0,0 +1,84 @@
+package nullify
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestValidateBatch(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	payloads := [][]byte{
+		[]byte(`{"id": "1", "kind": "created"}`),
+		[]byte(`{"kind": "created"}`),
+		[]byte(`{"id": "3", "kind": "updated"}`),
+		[]byte(`{`),
+	}
+
+	// Act
+	results := ValidateBatch(payloads, Event{}, validator.New())
+
+	// Assert
+	assert.Len(t, results, 4)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "1", *reflect.ValueOf(results[0].Presence).Elem().Field(0).Interface().(*string))
+	assert.Equal(t, ValidationErrors{{Path: "id", Tag: "required"}}, results[1].Err)
+	assert.NotNil(t, results[1].Presence)
+	assert.Equal(t, ValidationErrors{{Path: "kind", Tag: "oneof", Param: "created deleted"}}, results[2].Err)
+	assert.Error(t, results[3].Err)
+	assert.Nil(t, results[3].Presence)
+}
+
+func TestValidateBatch_Order(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	payloads := make([][]byte, 1000)
+	for i := range payloads {
+		payloads[i] = []byte(`{"id": "` + strconv.Itoa(i) + `"}`)
+	}
+
+	// Act
+	results := ValidateBatch(payloads, Event{}, validator.New())
+
+	// Assert
+	for i, result := range results {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, strconv.Itoa(i), *reflect.ValueOf(result.Presence).Elem().Field(0).Interface().(*string))
+	}
+}
+
+func TestValidateBatch_Empty(t *testing.T) {
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	assert.Empty(t, ValidateBatch(nil, Event{}, validator.New()))
+	assert.Len(t, ValidateBatch([][]byte{[]byte(`{}`)}, nil, validator.New()), 1)
+}
+
+func TestValidateBatch_ValueResult(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	// Act
+	results := ValidateBatch([][]byte{[]byte(`{"id": "1"}`)}, Event{}, validator.New(), ValueResult{Value: true})
+
+	// Assert
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "1", *reflect.ValueOf(results[0].Presence).Elem().Field(0).Interface().(*string))
+}
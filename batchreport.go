@@ -0,0 +1,96 @@
+package nullify
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// BatchReport is the aggregated result of validating a batch of documents against the same prototype
+type BatchReport struct {
+	Documents   int                `json:"documents"`   // number of documents in the batch
+	Failed      []int              `json:"failed"`      // indices of the undecodable or invalid documents
+	MissingRate map[string]float64 `json:"missingRate"` // fraction of decoded documents missing a field
+	Violations  []ViolationCount   `json:"violations"`  // validation failures, most common first
+}
+
+// ViolationCount is the number of documents failing the validation tag of a field
+type ViolationCount struct {
+	Path  string `json:"path"`  // json path of the field, e.g. address.street or tags[0]
+	Tag   string `json:"tag"`   // validation tag that failed, e.g. required
+	Count int    `json:"count"` // number of documents failing the tag
+}
+
+// ValidateDocuments decodes and validates each JSON document like ValidateBatch does and aggregates the results into
+// a BatchReport: the indices of the failing documents, the most common violations and, for every (nested) field of
+// the prototype, the rate of decoded documents in which it is missing. Paths are dotted json paths like the keys of
+// Flatten. Documents that cannot be decoded are failed but do not count towards the missing rates.
+func ValidateDocuments(payloads [][]byte, prototype any, v StructValidator, options ...option) *BatchReport {
+	report := &BatchReport{Documents: len(payloads), Failed: []int{}, MissingRate: map[string]float64{}}
+	instance := newNullified(prototype, options...)
+	if instance == nil {
+		return report
+	}
+
+	cfg := newConfig(options...)
+	paths := fieldPaths(reflect.TypeOf(instance).Elem(), "", cfg)
+	present := make(map[string]int, len(paths))
+	counts := map[ViolationCount]int{}
+	decoded := 0
+	for i, result := range ValidateBatch(payloads, prototype, v, options...) {
+		if result.Err != nil {
+			report.Failed = append(report.Failed, i)
+		}
+
+		var errs ValidationErrors
+		if errors.As(result.Err, &errs) {
+			for _, fieldError := range errs {
+				counts[ViolationCount{Path: fieldError.Path, Tag: fieldError.Tag}]++
+			}
+		}
+
+		if result.Presence == nil {
+			continue
+		}
+		decoded++
+		for path := range Flatten(result.Presence, options...) {
+			present[path]++
+		}
+	}
+
+	for _, path := range paths {
+		if decoded > 0 {
+			report.MissingRate[path] = float64(decoded-present[path]) / float64(decoded)
+		}
+	}
+
+	report.Violations = make([]ViolationCount, 0, len(counts))
+	for violation, count := range counts {
+		violation.Count = count
+		report.Violations = append(report.Violations, violation)
+	}
+	sort.Slice(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Tag < b.Tag
+	})
+	return report
+}
+
+// fieldPaths returns the dotted json paths of the leaf fields of the nullified struct t, named like Flatten does
+func fieldPaths(t reflect.Type, path string, cfg config) []string {
+	var paths []string
+	for _, encoded := range encodedFields(t, cfg) {
+		if fieldType := indirectType(encoded.field.Type); isNullifiedStruct(fieldType) {
+			paths = append(paths, fieldPaths(fieldType, path+encoded.name+".", cfg)...)
+		} else {
+			paths = append(paths, path+encoded.name)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,110 @@
+package nullify
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateDocuments(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Event struct {
+		ID      string  `json:"id" validate:"required"`
+		Kind    string  `json:"kind" validate:"omitnil,oneof=created deleted"`
+		Address Address `json:"address" validate:"omitnil"`
+	}
+
+	payloads := [][]byte{
+		[]byte(`{"id": "1", "kind": "created", "address": {"street": "Main St"}}`),
+		[]byte(`{"kind": "created"}`),
+		[]byte(`{"kind": "updated"}`),
+		[]byte(`{"id": "4", "address": {}}`),
+		[]byte(`{`),
+	}
+
+	// Act
+	report := ValidateDocuments(payloads, Event{}, validator.New())
+
+	// Assert
+	assert.Equal(t, &BatchReport{
+		Documents: 5,
+		Failed:    []int{1, 2, 3, 4},
+		MissingRate: map[string]float64{
+			"id":             0.5,
+			"kind":           0.25,
+			"address.street": 0.75,
+		},
+		Violations: []ViolationCount{
+			{Path: "id", Tag: "required", Count: 2},
+			{Path: "address.street", Tag: "required", Count: 1},
+			{Path: "kind", Tag: "oneof", Count: 1},
+		},
+	}, report)
+}
+
+func TestValidateDocuments_Empty(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Event struct {
+		ID      string  `json:"id" validate:"required"`
+		Kind    string  `json:"kind" validate:"omitnil,oneof=created deleted"`
+		Address Address `json:"address" validate:"omitnil"`
+	}
+
+	// Act
+	report := ValidateDocuments(nil, Event{}, validator.New())
+
+	// Assert
+	expected := &BatchReport{Failed: []int{}, MissingRate: map[string]float64{}, Violations: []ViolationCount{}}
+	assert.Equal(t, expected, report)
+}
+
+func TestValidateDocuments_ValueResult(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Event struct {
+		ID      string  `json:"id" validate:"required"`
+		Kind    string  `json:"kind" validate:"omitnil,oneof=created deleted"`
+		Address Address `json:"address" validate:"omitnil"`
+	}
+
+	payloads := [][]byte{[]byte(`{"id": "1", "kind": "created", "address": {"street": "Main St"}}`)}
+
+	// Act
+	report := ValidateDocuments(payloads, Event{}, validator.New(), ValueResult{Value: true})
+
+	// Assert
+	assert.Equal(t, 1, report.Documents)
+	assert.Empty(t, report.Failed)
+	assert.Equal(t, map[string]float64{"id": 0, "kind": 0, "address.street": 0}, report.MissingRate)
+}
+
+func TestValidateDocuments_Embedded(t *testing.T) {
+	// Arrange
+	type Customer struct {
+		ID   string
+		Name string `json:"name"`
+	}
+	type Supplier struct {
+		ID string
+	}
+	type Contract struct {
+		Customer
+		Supplier
+		Title string `json:"title"`
+	}
+	payloads := [][]byte{[]byte(`{"ID": "1", "name": "alice"}`), []byte(`{"title": "lease"}`)}
+
+	// Act
+	report := ValidateDocuments(payloads, Contract{}, validator.New())
+
+	// Assert
+	assert.Equal(t, map[string]float64{"name": 0.5, "title": 0.5}, report.MissingRate)
+}
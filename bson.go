@@ -0,0 +1,56 @@
+package nullify
+
+import (
+	"reflect"
+	"strings"
+)
+
+// BsonSet returns the fields of the nullified value that are set (non-nil) as a MongoDB `$set` document, e.g.
+// `coll.UpdateOne(ctx, filter, bson.M{"$set": nullify.BsonSet(patch)})`. Nested structs are addressed with
+// dotted paths (`address.city`) such that only the provided subfields are updated. The result is a plain map
+// which is assignable to bson.M.
+//
+// Field names follow the mongo driver: the name from the `bson` tag or the lower-cased field name. Fields tagged
+// `bson:"-"` are skipped and structs tagged `bson:",inline"` are flattened. WithTagPriority takes precedence over the
+// `bson` tag for naming fields.
+func BsonSet(nullified any, options ...option) map[string]any {
+	set := map[string]any{}
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && v.Kind() == reflect.Struct {
+		bsonSet(set, v, "", newConfig(options...))
+	}
+	return set
+}
+
+// bsonSet adds the set fields of the struct value v to set, prefixing names with the dotted path
+func bsonSet(set map[string]any, v reflect.Value, path string, cfg config) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if priority, found := priorityName(field, cfg); found {
+			name = priority
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		if isNullifiedStruct(value.Type()) {
+			if hasOption(opts, "inline") {
+				bsonSet(set, value, path, cfg)
+			} else {
+				bsonSet(set, value, path+name+".", cfg)
+			}
+			continue
+		}
+
+		set[path+name] = value.Interface()
+	}
+}
@@ -0,0 +1,67 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBsonSet(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		UpdatedBy string `bson:"updated_by"`
+	}
+	type Address struct {
+		Street string `bson:"street"`
+		City   string `bson:"city"`
+	}
+	type User struct {
+		Audit   `bson:",inline"`
+		ID      string   `bson:"_id"`
+		Name    string   `bson:"name,omitempty"`
+		Tags    []string `bson:"tags"`
+		Secret  string   `bson:"-"`
+		Age     int
+		Address Address `bson:"address"`
+	}
+
+	patch := Nullify(User{})
+	err := json.Unmarshal([]byte(`{
+		"UpdatedBy": "admin",
+		"Name": "alice",
+		"Tags": ["a"],
+		"Secret": "x",
+		"Age": 30,
+		"Address": {"City": "Springfield"}
+	}`), patch)
+	assert.Nil(t, err)
+
+	// Act
+	set := BsonSet(patch)
+
+	// Assert
+	tag := "a"
+	assert.Equal(t, map[string]any{
+		"updated_by":   "admin",
+		"name":         "alice",
+		"tags":         []*string{&tag},
+		"age":          30,
+		"address.city": "Springfield",
+	}, set)
+}
+
+func TestBsonSet_BareContainers(t *testing.T) {
+	// Arrange
+	type User struct {
+		Name string   `bson:"name"`
+		Tags []string `bson:"tags"`
+	}
+	patch := Nullify(User{}, BareContainers{Value: true})
+	assert.Nil(t, json.Unmarshal([]byte(`{"Name": "alice"}`), patch))
+
+	// Act
+	set := BsonSet(patch, BareContainers{Value: true})
+
+	// Assert
+	assert.Equal(t, map[string]any{"name": "alice"}, set)
+}
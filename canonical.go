@@ -0,0 +1,233 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalCanonical returns the canonical JSON encoding of the nullified value, e.g. for signing or as a cache key:
+// only set (non-nil) fields are emitted, object keys are sorted, there is no insignificant whitespace, HTML
+// characters are not escaped and numbers are formatted consistently (integers as is, other numbers in their
+// shortest form like JavaScript does), such that Equal values marshal to identical bytes.
+//
+// Field names follow encoding/json, embedded structs without a json name are flattened into their parent. Values
+// that are not nullified structs, slices or maps (e.g. time.Time) are encoded by encoding/json first, including any
+// MarshalJSON method, and then canonicalized. Nil elements of slices and maps are null.
+func MarshalCanonical(nullified any) ([]byte, error) {
+	value, err := canonical(reflect.ValueOf(nullified), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// canonical converts v into a tree of map[string]any, []any, json.Number, string, bool and nil
+func canonical(v reflect.Value, path string) (any, error) {
+	v, ok := indirect(v)
+	if !ok {
+		return nil, nil
+	}
+
+	switch {
+	case v.Type().Implements(jsonMarshaler):
+		// encoded by encoding/json below
+	case isNullifiedStruct(v.Type()):
+		fields := map[string]any{}
+		if err := canonicalStruct(fields, v, path); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8, v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		elems := make([]any, v.Len())
+		for i := range elems {
+			elem, err := canonical(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	case v.Kind() == reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := canonicalKey(iter.Key())
+			if err != nil {
+				return nil, fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+			}
+			if entries[key], err = canonical(iter.Value(), fmt.Sprintf("%s[%s]", path, key)); err != nil {
+				return nil, err
+			}
+		}
+		return entries, nil
+	}
+
+	if !v.CanInterface() {
+		return nil, nil
+	}
+	b, err := json.Marshal(addressable(v).Interface()) // pointer receivers, e.g. big.Int
+	if err != nil {
+		return nil, fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+	}
+	return value, nil
+}
+
+// canonicalStruct adds the set fields of the nullified struct v to fields by their json name, resolving the names
+// of embedded fields like encoding/json does (see encodedFields)
+func canonicalStruct(fields map[string]any, v reflect.Value, path string) error {
+	for _, encoded := range encodedFields(v.Type(), config{}) {
+		field, ok := encoded.value(v)
+		if !ok {
+			continue
+		}
+		value, ok := indirect(field)
+		if !ok {
+			continue
+		}
+
+		elem, err := canonical(value, joinPath(path, encoded.path))
+		if err != nil {
+			return err
+		}
+		_, opts, _ := strings.Cut(encoded.field.Tag.Get("json"), ",")
+		if hasOption(opts, "string") && isQuotable(value.Kind()) {
+			// the json encoding of the value inside a string, like encoding/json does for `,string`
+			var quoted bytes.Buffer
+			if err := writeCanonical(&quoted, elem); err != nil {
+				return err
+			}
+			elem = quoted.String()
+		}
+		fields[encoded.name] = elem
+	}
+	return nil
+}
+
+// canonicalKey returns the json object key of the map key v like encoding/json does
+func canonicalKey(v reflect.Value) (string, error) {
+	v, ok := indirect(v)
+	if !ok {
+		return "", fmt.Errorf("nil map key")
+	}
+
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", v.Type())
+}
+
+// writeCanonical writes the canonical encoding of the tree value to buf
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case string:
+		writeCanonicalString(buf, value)
+	case json.Number:
+		number, err := canonicalNumber(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, value[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("nullify: cannot canonicalize %T", value)
+	}
+	return nil
+}
+
+// writeCanonicalString writes the json string s to buf without escaping HTML characters
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	buf.Truncate(buf.Len() - 1) // trailing newline written by Encode
+}
+
+// canonicalNumber formats the json number n: integers are kept as is (without a negative zero), other numbers are
+// formatted in their shortest form using an exponent only for very small or large magnitudes
+func canonicalNumber(n json.Number) (string, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return strconv.FormatInt(i, 10), nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return strconv.FormatUint(u, 10), nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", fmt.Errorf("nullify: invalid number %s: %w", n, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := max(f, -f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	return mantissa + "e" + exponent[:1] + strings.TrimLeft(exponent[1:], "0"), nil // 1e-07 becomes 1e-7
+}
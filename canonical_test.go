@@ -0,0 +1,152 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name     string            `json:"name"`
+		Bio      string            `json:"bio"`
+		Score    float64           `json:"score"`
+		Big      uint64            `json:"big"`
+		Count    int               `json:"count,string"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Counts   map[int]int       `json:"counts"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Secret   string            `json:"-"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected string
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: `{}`,
+		},
+		"set fields": {
+			Payload: `{"name": "alice", "id": "1", "address": {"street": "Main St"}, "tags": ["b", null, "a"],
+				"labels": {"z": "1", "a": "2"}, "counts": {"10": 1, "2": 2}, "secret": "x"}`,
+			Expected: `{"address":{"street":"Main St"},"counts":{"10":1,"2":2},"id":"1","labels":{"a":"2","z":"1"},` +
+				`"name":"alice","tags":["b",null,"a"]}`,
+		},
+		"numbers": {
+			Payload:  `{"score": 1e2, "big": 18446744073709551615}`,
+			Expected: `{"big":18446744073709551615,"score":100}`,
+		},
+		"small number": {
+			Payload:  `{"score": 0.0000001}`,
+			Expected: `{"score":1e-7}`,
+		},
+		"large number": {
+			Payload:  `{"score": 1.5e300}`,
+			Expected: `{"score":1.5e+300}`,
+		},
+		"string option": {
+			Payload:  `{"count": "42"}`,
+			Expected: `{"count":"42"}`,
+		},
+		"html and unicode": {
+			Payload:  `{"bio": "<b>é</b> & more"}`,
+			Expected: `{"bio":"<b>é</b> & more"}`,
+		},
+		"leaf with MarshalJSON": {
+			Payload:  `{"birthday": "2000-01-02T10:00:00Z"}`,
+			Expected: `{"birthday":"2000-01-02T10:00:00Z"}`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			b, err := MarshalCanonical(p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, string(b))
+		})
+	}
+}
+
+func TestMarshalCanonical_Nil(t *testing.T) {
+	// Act
+	b, err := MarshalCanonical(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestMarshalCanonical_EmbeddedConflicts(t *testing.T) {
+	type Other struct {
+		ID   string
+		Name string
+	}
+	type Untagged struct {
+		ID string
+	}
+	type Shadow struct {
+		ID string
+		Untagged
+	}
+	type Ambiguous struct {
+		Untagged
+		Other
+	}
+
+	tests := map[string]struct {
+		Original any
+		Expected string
+	}{
+		"shallowest wins": {
+			Original: &Shadow{ID: "outer", Untagged: Untagged{ID: "inner"}},
+			Expected: `{"ID":"outer"}`,
+		},
+		"ambiguous dropped": {
+			Original: &Ambiguous{
+				Untagged: Untagged{ID: "a"},
+				Other:    Other{ID: "b", Name: "n"},
+			},
+			Expected: `{"Name":"n"}`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(testData.Original)
+			if err := CopyMatching(testData.Original, p, MatchFields{Value: MatchGoName}); err != nil {
+				t.Fatal(err)
+			}
+			expected, _ := MarshalCanonical(testData.Original)
+
+			// Act
+			b, err := MarshalCanonical(p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, string(b))
+			assert.Equal(t, string(expected), string(b))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package nullify
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of the nullified value with freshly allocated pointers, slices and maps, such that the
+// copy can be mutated (e.g. for a retry or dry run) without affecting the original. Nil fields stay nil, so which
+// fields are set is preserved. Unexported fields and values of kind chan and func are copied shallowly. The value
+// must not contain cycles.
+func Clone(nullified any) any {
+	if nullified == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(nullified)).Interface()
+}
+
+// cloneValue returns a deep copy of v
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneValue(v.Elem()))
+		return c
+	case reflect.Interface:
+		c := reflect.New(v.Type()).Elem()
+		if !v.IsNil() {
+			c.Set(cloneValue(v.Elem()))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(cloneValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(cloneValue(iter.Key()), cloneValue(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,58 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name     string            `json:"name"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Extra    any               `json:"extra"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Work     *Address          `json:"work"`
+	}
+
+	p := Nullify(Person{})
+	payload := `{"name": "alice", "tags": ["a", null], "labels": {"k": "v"}, "extra": {"x": [1]},
+		"birthday": "2000-01-02T00:00:00Z", "address": {"city": "Springfield"}}`
+	if err := json.Unmarshal([]byte(payload), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	c := Clone(p)
+
+	// Assert
+	expected, _ := json.Marshal(p)
+	actual, _ := json.Marshal(c)
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Equal(t, Flatten(p), Flatten(c))
+
+	// the clone shares no pointers with the original
+	original, clone := reflect.ValueOf(p).Elem(), reflect.ValueOf(c).Elem()
+	*clone.FieldByName("Address").Elem().FieldByName("City").Interface().(*string) = "Shelbyville"
+	assert.Equal(t, "Springfield", Flatten(p)["address.city"])
+	for _, name := range []string{"Name", "Tags", "Labels", "Birthday", "Address"} {
+		assert.NotEqual(t, original.FieldByName(name).Pointer(), clone.FieldByName(name).Pointer(), name)
+	}
+	assert.NotSame(t, (*original.FieldByName("Tags").Interface().(*[]*string))[0],
+		(*clone.FieldByName("Tags").Interface().(*[]*string))[0])
+	assert.True(t, clone.FieldByName("Work").IsNil())
+}
+
+func TestClone_Nil(t *testing.T) {
+	// Act & Assert
+	assert.Nil(t, Clone(nil))
+}
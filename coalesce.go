@@ -0,0 +1,19 @@
+package nullify
+
+import (
+	"reflect"
+)
+
+// Coalesce returns fallback with the fields that are set in the nullified value copied over it (matched by json name
+// as with CopyMatching), such that unset fields keep the value of fallback. Values that fallback points to are not
+// modified.
+func Coalesce[T any](nullified any, fallback T, options ...option) (T, error) {
+	cfg := newConfig(options...)
+	cfg.copyOnWrite = true
+
+	result := fallback
+	if err := copyValue(reflect.ValueOf(&result).Elem(), reflect.ValueOf(nullified), "", cfg); err != nil {
+		return fallback, err
+	}
+	return result, nil
+}
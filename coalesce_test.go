@@ -0,0 +1,90 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCoalesce(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Address *Address `json:"address"`
+	}
+
+	fallback := Person{
+		Name:    "alice",
+		Age:     30,
+		Tags:    []string{"a"},
+		Address: &Address{Street: "Main St", City: "Springfield"},
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected Person
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: fallback,
+		},
+		"override": {
+			Payload: `{"age": 0, "tags": ["b", "c"], "address": {"city": "Shelbyville"}}`,
+			Expected: Person{
+				Name:    "alice",
+				Age:     0,
+				Tags:    []string{"b", "c"},
+				Address: &Address{Street: "Main St", City: "Shelbyville"},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			actual, err := Coalesce(p, fallback)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testData.Expected, actual)
+			assert.Equal(t, "Springfield", fallback.Address.City)
+		})
+	}
+}
+
+func TestCoalesce_Error(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Address *Address `json:"address"`
+	}
+
+	fallback := Person{Name: "alice"}
+	src := &struct {
+		Name *int `json:"name"`
+	}{Name: new(int)}
+
+	// Act
+	actual, err := Coalesce(src, fallback)
+
+	// Assert
+	assert.EqualError(t, err, "nullify: Name: cannot copy int into string")
+	assert.Equal(t, fallback, actual)
+}
@@ -0,0 +1,72 @@
+package nullify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// compiled caches the nullified types by original type and options, see compiledKey
+var compiled sync.Map
+
+// compiledKey identifies a nullified type by the original type and the (comparable) options it was built with
+type compiledKey struct {
+	t       reflect.Type
+	options string
+}
+
+// newCompiledKey returns the cache key of t nullified with options, false if an option cannot be compared (e.g.
+// because it holds a function or slice) in which case the nullified type is not cached
+func newCompiledKey(t reflect.Type, options []option) (compiledKey, bool) {
+	var b strings.Builder
+	for _, opt := range options {
+		if v := reflect.ValueOf(opt); !v.IsValid() || !v.Comparable() {
+			return compiledKey{}, false
+		}
+		fmt.Fprintf(&b, "%#v;", opt)
+	}
+	return compiledKey{t: t, options: b.String()}, true
+}
+
+// Compile nullifies the type of prototype like NewNullifyResult does, but returns an error instead of panicking
+// (e.g. for recursive types without OnRecursion) or when options conflict, see NullifyE. Nullified types built with
+// options that do not hold functions or slices are cached, such that compiling them at startup pre-builds the types
+// used by Nullify and friends later on. See Stats and OnCompile for the counters of the cache.
+func Compile(prototype any, options ...option) (result *NullifyResult, err error) {
+	if reflect.TypeOf(prototype) == nil {
+		return nil, fmt.Errorf("nullify: cannot compile nil")
+	}
+	if err := newConfig(options...).validate(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("nullify: cannot compile %T: %v", prototype, r)
+		}
+	}()
+	return NewNullifyResult(prototype, options...), nil
+}
+
+// CompileAll compiles each prototype (see Compile), e.g. to fail fast on unsupported structs at startup rather
+// than on the first request. It returns the errors of all prototypes that could not be compiled joined together.
+func CompileAll(prototypes []any, options ...option) error {
+	var errs []error
+	for _, prototype := range prototypes {
+		if _, err := Compile(prototype, options...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CompileTypes is CompileAll for a list of types, e.g. reflect.TypeOf(Person{}), rather than prototype values
+func CompileTypes(types []reflect.Type, options ...option) error {
+	prototypes := make([]any, len(types))
+	for i, t := range types {
+		prototypes[i] = reflect.New(t).Elem().Interface()
+	}
+	return CompileAll(prototypes, options...)
+}
@@ -0,0 +1,127 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+
+	// Act
+	result, err := Compile(Person{}, JsonOptions...)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{}, JsonOptions...)), result.Type)
+}
+
+func TestCompile_Errors(t *testing.T) {
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	tests := map[string]struct {
+		Prototype    any
+		ErrorMessage string
+	}{
+		"nil": {
+			Prototype:    nil,
+			ErrorMessage: "nullify: cannot compile nil",
+		},
+		"recursive": {
+			Prototype: Node{},
+			ErrorMessage: "nullify: cannot compile nullify.Node: nullify: nullify.Node is recursive, " +
+				"use OnRecursion to substitute recursive references",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := Compile(testData.Prototype)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestCompileAll(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	// Act
+	err := CompileAll([]any{Person{}, Node{}, nil})
+
+	// Assert
+	assert.ErrorContains(t, err, "nullify: cannot compile nullify.Node")
+	assert.ErrorContains(t, err, "nullify: cannot compile nil")
+	assert.NoError(t, CompileAll([]any{Person{}, Node{}}, OnRecursion{Value: RecursionAny}))
+}
+
+func TestCompileTypes(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	// Act
+	err := CompileTypes([]reflect.Type{reflect.TypeOf(Person{}), reflect.TypeOf(Node{})})
+
+	// Assert
+	assert.ErrorContains(t, err, "nullify: cannot compile nullify.Node")
+}
+
+func TestNullify_Cached(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string
+	}
+	transform := WithFieldTransform(func(field reflect.StructField) reflect.StructField { return field })
+
+	tests := map[string]struct {
+		Options []option
+		Cached  bool
+	}{
+		"no options":         {Options: nil, Cached: true},
+		"comparable options": {Options: JsonOptions, Cached: true},
+		"function option":    {Options: []option{transform}, Cached: false},
+		"slice option":       {Options: []option{StripTags{Value: []string{"db"}}}, Cached: false},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Person{}, testData.Options...)
+			key, cacheable := newCompiledKey(reflect.TypeOf(Person{}), testData.Options)
+			typ, ok := compiled.Load(key)
+
+			// Assert
+			assert.Equal(t, testData.Cached, cacheable)
+			assert.Equal(t, testData.Cached, ok)
+			if ok {
+				assert.Equal(t, reflect.TypeOf(p), typ)
+			}
+		})
+	}
+}
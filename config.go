@@ -0,0 +1,227 @@
+package nullify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Config is a snapshot of the effective configuration resolved from a list of options, e.g. to log which options
+// were in force when a type was nullified. Options that hold functions are reported by their number.
+type Config struct {
+	BytesAsString        bool
+	NullifyArrayElem     bool
+	NullifySliceElem     bool
+	NullifyMapElem       bool
+	NullifyMapKey        bool
+	NullifyMarshalJson   bool
+	NullifyUnmarshalJson bool
+	NullifySqlScanner    bool
+	NullifyStructFields  bool
+	KeepNamedBytes       bool
+	OmitEmpty            bool
+	OmitZero             bool
+	ValidateRequired     bool
+	OmitNil              bool
+	NilAsZero            bool
+	FlattenEmbedded      bool
+	SafeMapKeys          bool
+	StringMapKeys        bool
+	JsonNumbers          bool
+	NullifyInterfaceElem bool
+	SkipUnserializable   bool
+	BareContainers       bool
+	ValueResult          bool
+	Protobuf             bool
+	Recursion            Recursion
+	ByteEncoding         ByteEncoding
+	Matching             Matching
+	StripTags            []string
+	TagPriority          []string
+	TagRemaps            []string                      // keys of the tags remapped with RemapTag
+	FieldTransforms      int                           // number of WithFieldTransform options
+	FieldNameFuncs       int                           // number of WithFieldNameFunc options
+	FieldOptions         []string                      // paths of the WithFieldOptions options
+	Transformers         int                           // number of Transform options
+	InterfaceImpls       map[reflect.Type]reflect.Type // WithInterfaceImpl by interface type
+	TypeOverrides        map[reflect.Type]reflect.Type // WithTypeOverride by original type
+}
+
+// ResolveOptions returns the configuration that results from applying options to the defaults, as used by Nullify
+func ResolveOptions(options ...option) Config {
+	cfg := newConfig(options...)
+	resolved := Config{
+		BytesAsString:        cfg.bytesAsString,
+		NullifyArrayElem:     cfg.nullifyArrayElem,
+		NullifySliceElem:     cfg.nullifySliceElem,
+		NullifyMapElem:       cfg.nullifyMapElem,
+		NullifyMapKey:        cfg.nullifyMapKey,
+		NullifyMarshalJson:   cfg.nullifyMarshalJson,
+		NullifyUnmarshalJson: cfg.nullifyUnmarshalJson,
+		NullifySqlScanner:    cfg.nullifySqlScanner,
+		NullifyStructFields:  cfg.nullifyStructFields,
+		KeepNamedBytes:       cfg.keepNamedBytes,
+		OmitEmpty:            cfg.omitEmpty,
+		OmitZero:             cfg.omitZero,
+		ValidateRequired:     cfg.validateRequired,
+		OmitNil:              cfg.omitNil,
+		NilAsZero:            cfg.nilAsZero,
+		FlattenEmbedded:      cfg.flattenEmbedded,
+		SafeMapKeys:          cfg.safeMapKeys,
+		StringMapKeys:        cfg.stringMapKeys,
+		JsonNumbers:          cfg.jsonNumbers,
+		NullifyInterfaceElem: cfg.nullifyInterfaceElem,
+		SkipUnserializable:   cfg.skipUnserializable,
+		BareContainers:       cfg.bareContainers,
+		ValueResult:          cfg.valueResult,
+		Protobuf:             cfg.protobuf,
+		Recursion:            cfg.recursion,
+		ByteEncoding:         cfg.byteEncoding,
+		Matching:             cfg.matching,
+		StripTags:            slices.Clone(cfg.stripTags),
+		TagPriority:          slices.Clone(cfg.tagPriority),
+		FieldTransforms:      len(cfg.fieldTransforms),
+		FieldNameFuncs:       len(cfg.fieldNameFuncs),
+		Transformers:         len(cfg.transformers),
+		InterfaceImpls:       map[reflect.Type]reflect.Type{},
+		TypeOverrides:        map[reflect.Type]reflect.Type{},
+	}
+	for _, remap := range cfg.tagRemaps {
+		resolved.TagRemaps = append(resolved.TagRemaps, remap.Key)
+	}
+	for _, override := range cfg.fieldOptions {
+		resolved.FieldOptions = append(resolved.FieldOptions, override.path)
+	}
+	for iface, impl := range cfg.interfaceImpls {
+		resolved.InterfaceImpls[iface] = impl
+	}
+	for from, to := range cfg.typeOverrides {
+		resolved.TypeOverrides[from] = to
+	}
+	return resolved
+}
+
+// String returns the configuration on a single line, e.g. for logging: the flags that are set, the enumerations
+// and lists that differ from their defaults and the type mappings
+func (c Config) String() string {
+	var parts []string
+	flags := []struct {
+		name  string
+		value bool
+	}{
+		{"BytesAsString", c.BytesAsString},
+		{"NullifyArrayElem", c.NullifyArrayElem},
+		{"NullifySliceElem", c.NullifySliceElem},
+		{"NullifyMapElem", c.NullifyMapElem},
+		{"NullifyMapKey", c.NullifyMapKey},
+		{"NullifyMarshalJson", c.NullifyMarshalJson},
+		{"NullifyUnmarshalJson", c.NullifyUnmarshalJson},
+		{"NullifySqlScanner", c.NullifySqlScanner},
+		{"NullifyStructFields", c.NullifyStructFields},
+		{"KeepNamedBytes", c.KeepNamedBytes},
+		{"OmitEmpty", c.OmitEmpty},
+		{"OmitZero", c.OmitZero},
+		{"ValidateRequired", c.ValidateRequired},
+		{"OmitNil", c.OmitNil},
+		{"NilAsZero", c.NilAsZero},
+		{"FlattenEmbedded", c.FlattenEmbedded},
+		{"SafeMapKeys", c.SafeMapKeys},
+		{"StringMapKeys", c.StringMapKeys},
+		{"JsonNumbers", c.JsonNumbers},
+		{"NullifyInterfaceElem", c.NullifyInterfaceElem},
+		{"SkipUnserializable", c.SkipUnserializable},
+		{"BareContainers", c.BareContainers},
+		{"ValueResult", c.ValueResult},
+		{"Protobuf", c.Protobuf},
+	}
+	for _, flag := range flags {
+		if flag.value {
+			parts = append(parts, flag.name)
+		}
+	}
+
+	if c.Recursion != RecursionPanic {
+		parts = append(parts, "Recursion="+c.Recursion.String())
+	}
+	if c.ByteEncoding != ByteEncodingBase64 {
+		parts = append(parts, "ByteEncoding="+c.ByteEncoding.String())
+	}
+	if c.Matching != MatchJsonName {
+		parts = append(parts, "Matching="+c.Matching.String())
+	}
+
+	lists := []struct {
+		name   string
+		values []string
+	}{
+		{"StripTags", c.StripTags},
+		{"TagPriority", c.TagPriority},
+		{"TagRemaps", c.TagRemaps},
+		{"FieldOptions", c.FieldOptions},
+	}
+	for _, list := range lists {
+		if len(list.values) > 0 {
+			parts = append(parts, list.name+"=["+strings.Join(list.values, ",")+"]")
+		}
+	}
+	if c.FieldTransforms > 0 {
+		parts = append(parts, fmt.Sprintf("FieldTransforms=%d", c.FieldTransforms))
+	}
+	if c.FieldNameFuncs > 0 {
+		parts = append(parts, fmt.Sprintf("FieldNameFuncs=%d", c.FieldNameFuncs))
+	}
+	if c.Transformers > 0 {
+		parts = append(parts, fmt.Sprintf("Transformers=%d", c.Transformers))
+	}
+
+	mappings := []struct {
+		name  string
+		types map[reflect.Type]reflect.Type
+	}{
+		{"InterfaceImpls", c.InterfaceImpls},
+		{"TypeOverrides", c.TypeOverrides},
+	}
+	for _, mapping := range mappings {
+		if len(mapping.types) == 0 {
+			continue
+		}
+		entries := make([]string, 0, len(mapping.types))
+		for from, to := range mapping.types {
+			entries = append(entries, from.String()+"->"+to.String())
+		}
+		sort.Strings(entries)
+		parts = append(parts, mapping.name+"=["+strings.Join(entries, ",")+"]")
+	}
+
+	return "nullify.Config{" + strings.Join(parts, " ") + "}"
+}
+
+// validate returns the conflicts between the options that resulted in cfg joined together, nil if there are none:
+// a type override for []byte with BytesAsString, a type override and an interface implementation for the same type,
+// and a tag priority for a tag that is stripped
+func (cfg config) validate() error {
+	var errs []error
+	for from, to := range cfg.typeOverrides {
+		if cfg.bytesAsString && from.Kind() == reflect.Slice && from.Elem().Kind() == reflect.Uint8 {
+			errs = append(errs, fmt.Errorf("nullify: conflicting options: BytesAsString and WithTypeOverride(%s, %s)",
+				from, to))
+		}
+		if impl, ok := cfg.interfaceImpls[from]; ok {
+			errs = append(errs, fmt.Errorf("nullify: conflicting options: WithInterfaceImpl(%s, %s) and "+
+				"WithTypeOverride(%s, %s)", from, impl, from, to))
+		}
+	}
+
+	for _, key := range cfg.tagPriority {
+		if slices.Contains(cfg.stripTags, key) {
+			errs = append(errs, fmt.Errorf("nullify: conflicting options: WithTagPriority(%q) and StripTags "+
+				"removing %q", key, key))
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
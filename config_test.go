@@ -0,0 +1,111 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestResolveOptions(t *testing.T) {
+	// Arrange
+	options := append(slices.Clip(JsonOptions),
+		OnRecursion{Value: RecursionAny},
+		StripTags{Value: []string{"db", "gorm"}},
+		WithTagPriority("form"),
+		WithFieldTransform(func(field reflect.StructField) reflect.StructField { return field }),
+		WithTypeOverride(reflect.TypeOf(0), reflect.TypeOf("")),
+		WithFieldOptions("Address.City", OmitEmpty{Value: true}),
+	)
+
+	// Act
+	cfg := ResolveOptions(options...)
+
+	// Assert
+	assert.True(t, cfg.BytesAsString)
+	assert.False(t, cfg.NullifySliceElem)
+	assert.True(t, cfg.NullifySqlScanner)
+	assert.Equal(t, RecursionAny, cfg.Recursion)
+	assert.Equal(t, []string{"db", "gorm"}, cfg.StripTags)
+	assert.Equal(t, []string{"form"}, cfg.TagPriority)
+	assert.Equal(t, 1, cfg.FieldTransforms)
+	assert.Equal(t, []string{"Address.City"}, cfg.FieldOptions)
+	assert.Equal(t, map[reflect.Type]reflect.Type{reflect.TypeOf(0): reflect.TypeOf("")}, cfg.TypeOverrides)
+	assert.Equal(t, "nullify.Config{BytesAsString NullifySqlScanner NullifyStructFields SafeMapKeys "+
+		"SkipUnserializable Recursion=RecursionAny StripTags=[db,gorm] TagPriority=[form] FieldOptions=[Address.City] FieldTransforms=1 "+
+		"TypeOverrides=[int->string]}", cfg.String())
+}
+
+func TestResolveOptions_Default(t *testing.T) {
+	// Act
+	cfg := ResolveOptions()
+
+	// Assert
+	assert.Equal(t, "nullify.Config{NullifyArrayElem NullifySliceElem NullifyMapElem NullifyMapKey NullifySqlScanner "+
+		"NullifyStructFields}", cfg.String())
+}
+
+func TestResolveOptions_Enumerations(t *testing.T) {
+	assert.Equal(t, "RecursionOriginal", RecursionOriginal.String())
+	assert.Equal(t, "ByteEncodingHex", ByteEncodingHex.String())
+	assert.Equal(t, "MatchLoose", MatchLoose.String())
+	assert.Equal(t, "Matching(7)", Matching(7).String())
+}
+
+func TestNullifyE_Conflicts(t *testing.T) {
+	// Arrange
+	shapeType := reflect.TypeOf((*shape)(nil)).Elem()
+
+	tests := map[string]struct {
+		Options      []option
+		ErrorMessage string
+	}{
+		"BytesAsString and byte override": {
+			Options:      []option{BytesAsString{Value: true}, WithTypeOverride(reflect.TypeOf([]byte{}), reflect.TypeOf(""))},
+			ErrorMessage: "nullify: conflicting options: BytesAsString and WithTypeOverride([]uint8, string)",
+		},
+		"interface impl and override": {
+			Options: []option{WithInterfaceImpl[shape, square](), WithTypeOverride(shapeType, reflect.TypeOf(""))},
+			ErrorMessage: "nullify: conflicting options: WithInterfaceImpl(nullify.shape, nullify.square) and " +
+				"WithTypeOverride(nullify.shape, string)",
+		},
+		"stripped priority tag": {
+			Options:      []option{StripTags{Value: []string{"form"}}, WithTagPriority("form")},
+			ErrorMessage: `nullify: conflicting options: WithTagPriority("form") and StripTags removing "form"`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			nullified, err := NullifyE(struct{ Name string }{}, testData.Options...)
+			result, compileErr := Compile(struct{ Name string }{}, testData.Options...)
+
+			// Assert
+			assert.Nil(t, nullified)
+			assert.EqualError(t, err, testData.ErrorMessage)
+			assert.Nil(t, result)
+			assert.EqualError(t, compileErr, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestNullifyE(t *testing.T) {
+	// Arrange
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	// Act
+	nullified, err := NullifyE(struct{ Name string }{}, JsonOptions...)
+	_, nilErr := NullifyE(nil)
+	_, recursiveErr := NullifyE(Node{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(Nullify(struct{ Name string }{}, JsonOptions...)), reflect.TypeOf(nullified))
+	assert.EqualError(t, nilErr, "nullify: cannot nullify nil")
+	assert.ErrorContains(t, recursiveErr, "nullify: cannot nullify nullify.Node: nullify: nullify.Node")
+}
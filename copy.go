@@ -0,0 +1,291 @@
+package nullify
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CopyMatching copies the fields of src into dst, matching struct fields by their json name (see MatchFields). Any
+// two struct types can be used, e.g. a nullified value and its original type, or a DTO and a domain model. Nil
+// pointers in src are treated as not present and leave the corresponding dst field untouched, such that a nullified
+// value can be applied as a patch. Nested structs, slices, arrays and maps are copied recursively.
+//
+// dst must be a non-nil pointer, src may be a value or a pointer.
+func CopyMatching(src any, dst any, options ...option) error {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Pointer || dstVal.IsNil() {
+		return fmt.Errorf("nullify: dst must be a non-nil pointer, got %T", dst)
+	}
+
+	return copyValue(dstVal.Elem(), reflect.ValueOf(src), "", newConfig(options...))
+}
+
+// copyValue recursively copies src into dst. Nil pointers and interfaces in src leave dst untouched, as do nil
+// slices and maps with BareContainers.
+func copyValue(dst reflect.Value, src reflect.Value, path string, cfg config) error {
+	for src.Kind() == reflect.Pointer || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if _, ok := present(src, cfg); !ok {
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			elem := reflect.New(dst.Type().Elem())
+			if err := copyValue(elem.Elem(), src, path, cfg); err != nil {
+				return err
+			}
+			dst.Set(elem)
+			return nil
+		}
+		if cfg.copyOnWrite {
+			elem := reflect.New(dst.Type().Elem())
+			elem.Elem().Set(dst.Elem())
+			if err := copyValue(elem.Elem(), src, path, cfg); err != nil {
+				return err
+			}
+			dst.Set(elem)
+			return nil
+		}
+		return copyValue(dst.Elem(), src, path, cfg)
+	}
+
+	if isTextLeaf(src.Type()) && src.Type() == dst.Type() {
+		// e.g. big.Int, which shares its digits with the copy when assigned
+		text, err := formatText(src)
+		if err == nil {
+			err = parseText(dst, text)
+		}
+		if err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 && src.Kind() == reflect.String:
+		// e.g. BytesAsString, where the string holds the representation selected by EncodeBytes
+		b, err := decodeBytes(src.String(), cfg.byteEncoding)
+		if err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+		dst.Set(reflect.ValueOf(b).Convert(dst.Type()))
+		return nil
+	case dst.Kind() == reflect.String && src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Uint8:
+		dst.SetString(encodeBytes(src.Bytes(), cfg.byteEncoding))
+		return nil
+	case src.Type() == jsonNumberType && isNumber(dst.Kind()):
+		// JsonNumbers, ParseInt and friends report numbers that overflow the type of dst
+		if err := parseText(dst, src.String()); err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+		return nil
+	case dst.Type() == jsonNumberType && isNumber(src.Kind()):
+		text, _ := formatText(src)
+		dst.SetString(text)
+		return nil
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.String && reflect.PointerTo(dst.Type()).Implements(textUnmarshaler):
+		// e.g. a decimal overridden to *string with WithTypeOverride
+		if err := parseText(dst, src.String()); err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+		return nil
+	case dst.Kind() == reflect.String && src.Kind() == reflect.Struct && reflect.PointerTo(src.Type()).Implements(textMarshaler):
+		text, err := formatText(src)
+		if err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+		dst.SetString(text)
+		return nil
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct:
+		return copyStruct(dst, src, path, cfg)
+	case dst.Kind() == reflect.Slice && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := copyValue(slice.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i), cfg); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case dst.Kind() == reflect.Array && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		if src.Len() > dst.Len() {
+			return fmt.Errorf("nullify: %s: cannot copy %d elements into %s", pathOrRoot(path), src.Len(), dst.Type())
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := copyValue(dst.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i), cfg); err != nil {
+				return err
+			}
+		}
+		return nil
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := copyMapKey(key, iter.Key(), path, cfg); err != nil {
+				return err
+			}
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := copyValue(elem, iter.Value(), fmt.Sprintf("%s[%v]", path, key), cfg); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		dst.Set(m)
+		return nil
+	case dst.Kind() == src.Kind() && src.Type().ConvertibleTo(dst.Type()):
+		// e.g. string into a named string type
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	default:
+		return fmt.Errorf("nullify: %s: cannot copy %s into %s", pathOrRoot(path), src.Type(), dst.Type())
+	}
+}
+
+// copyMapKey copies the map key src into dst, converting between string keys and keys of other types (see
+// StringMapKeys)
+func copyMapKey(dst reflect.Value, src reflect.Value, path string, cfg config) error {
+	src, ok := indirect(src)
+	if !ok {
+		return nil
+	}
+
+	dstType := dst.Type()
+	for dstType.Kind() == reflect.Pointer {
+		dstType = dstType.Elem()
+	}
+	switch {
+	case src.Kind() == reflect.String && dstType.Kind() != reflect.String:
+		if err := parseText(dst, src.String()); err != nil {
+			return fmt.Errorf("nullify: %s: key %q: %w", pathOrRoot(path), src.String(), err)
+		}
+		return nil
+	case dstType.Kind() == reflect.String && src.Kind() != reflect.String:
+		text, err := formatText(src)
+		if err != nil {
+			return fmt.Errorf("nullify: %s: key %v: %w", pathOrRoot(path), src, err)
+		}
+		return copyValue(dst, reflect.ValueOf(text), path, cfg)
+	default:
+		return copyValue(dst, src, path, cfg)
+	}
+}
+
+// copyStruct copies the fields of src into the fields of dst that match according to cfg.matching
+func copyStruct(dst reflect.Value, src reflect.Value, path string, cfg config) error {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		j, ok := matchField(src.Type(), field, cfg)
+		if !ok {
+			continue
+		}
+
+		if err := copyValue(dst.Field(i), src.Field(j), joinPath(path, field.Name), cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchField returns the index of the field of the struct type t matching field according to cfg.matching
+func matchField(t reflect.Type, field reflect.StructField, cfg config) (int, bool) {
+	name, hasName := fieldName(field, cfg)
+	if !hasName && cfg.matching != MatchGoName {
+		return 0, false
+	}
+
+	matches := []func(candidate reflect.StructField, candidateName string) bool{
+		func(candidate reflect.StructField, candidateName string) bool { return candidateName == name },
+	}
+	switch cfg.matching {
+	case MatchGoName:
+		matches = []func(reflect.StructField, string) bool{
+			func(candidate reflect.StructField, _ string) bool { return candidate.Name == field.Name },
+		}
+	case MatchLoose:
+		matches = append(matches,
+			func(candidate reflect.StructField, _ string) bool { return candidate.Name == field.Name },
+			func(candidate reflect.StructField, candidateName string) bool {
+				return strings.EqualFold(candidateName, name) || strings.EqualFold(candidate.Name, field.Name)
+			},
+		)
+	}
+
+	for _, match := range matches {
+		for i := 0; i < t.NumField(); i++ {
+			candidate := t.Field(i)
+			candidateName, ok := fieldName(candidate, cfg)
+			if !candidate.IsExported() || (!ok && cfg.matching != MatchGoName) {
+				continue
+			}
+			if match(candidate, candidateName) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// decodeBytes decodes s according to encoding
+func decodeBytes(s string, encoding ByteEncoding) ([]byte, error) {
+	switch encoding {
+	case ByteEncodingHex:
+		return hex.DecodeString(s)
+	case ByteEncodingRaw:
+		return []byte(s), nil
+	default:
+		return base64.StdEncoding.DecodeString(s)
+	}
+}
+
+// encodeBytes encodes b according to encoding
+func encodeBytes(b []byte, encoding ByteEncoding) string {
+	switch encoding {
+	case ByteEncodingHex:
+		return hex.EncodeToString(b)
+	case ByteEncodingRaw:
+		return string(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+// joinPath appends name to the dotted path
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// pathOrRoot returns path or a placeholder for the top-level value
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
@@ -0,0 +1,325 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCopyMatching_Patch(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string            `json:"name"`
+		Age     int               `json:"age"`
+		Tags    []string          `json:"tags"`
+		Labels  map[string]string `json:"labels"`
+		Address Address           `json:"address"`
+	}
+
+	person := Person{Name: "alice", Age: 30, Address: Address{Street: "Main St", City: "Springfield"}}
+	patch := Nullify(person)
+	err := json.Unmarshal([]byte(`{"age": 31, "tags": ["a"], "labels": {"k": "v"}, "address": {"city": "Shelbyville"}}`), patch)
+	assert.Nil(t, err)
+
+	// Act
+	err = CopyMatching(patch, &person)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Person{
+		Name:    "alice",
+		Age:     31,
+		Tags:    []string{"a"},
+		Labels:  map[string]string{"k": "v"},
+		Address: Address{Street: "Main St", City: "Shelbyville"},
+	}, person)
+}
+
+func TestCopyMatching_NilContainers(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+	type Patch struct {
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+	tests := map[string]struct {
+		Options  []option
+		Expected Person
+	}{
+		"default":        {Options: nil, Expected: Person{}},
+		"BareContainers": {Options: []option{BareContainers{Value: true}}, Expected: Person{Tags: []string{"a"}, Labels: map[string]string{"k": "v"}}},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			person := Person{Tags: []string{"a"}, Labels: map[string]string{"k": "v"}}
+
+			// Act
+			err := CopyMatching(Patch{}, &person, testData.Options...)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, person)
+		})
+	}
+}
+
+func TestCopyMatching_DifferentTypes(t *testing.T) {
+	// Arrange
+	type Status string
+	type Dto struct {
+		Identifier string `json:"id"`
+		Status     string `json:"status"`
+		Ignored    string `json:"-"`
+	}
+	type Model struct {
+		ID      string `json:"id"`
+		Status  Status `json:"status"`
+		Ignored string `json:"ignored"`
+	}
+
+	dto := Dto{Identifier: "1", Status: "active", Ignored: "x"}
+	var model Model
+
+	// Act
+	err := CopyMatching(dto, &model)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Model{ID: "1", Status: "active"}, model)
+}
+
+func TestCopyMatching_Errors(t *testing.T) {
+	type Src struct {
+		Value string `json:"value"`
+	}
+	type Dst struct {
+		Value int `json:"value"`
+	}
+
+	tests := map[string]struct {
+		Dst          any
+		ErrorMessage string
+	}{
+		"non-pointer dst":   {Dst: Dst{}, ErrorMessage: "nullify: dst must be a non-nil pointer, got nullify.Dst"},
+		"nil dst":           {Dst: (*Dst)(nil), ErrorMessage: "nullify: dst must be a non-nil pointer, got *nullify.Dst"},
+		"incompatible type": {Dst: &Dst{}, ErrorMessage: "nullify: Value: cannot copy string into int"},
+		"invalid base64": {Dst: &struct {
+			Value []byte `json:"value"`
+		}{}, ErrorMessage: "nullify: Value: illegal base64 data at input byte 0"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := CopyMatching(Src{Value: "1"}, testData.Dst)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestCopyMatching_EncodeBytes(t *testing.T) {
+	type Encoded struct {
+		Value string `json:"value"`
+	}
+	type Decoded struct {
+		Value []byte `json:"value"`
+	}
+
+	tests := map[string]struct {
+		Options []option
+		Encoded string
+	}{
+		"default":            {Options: nil, Encoded: "aGk="},
+		"ByteEncodingBase64": {Options: []option{EncodeBytes{Value: ByteEncodingBase64}}, Encoded: "aGk="},
+		"ByteEncodingHex":    {Options: []option{EncodeBytes{Value: ByteEncodingHex}}, Encoded: "6869"},
+		"ByteEncodingRaw":    {Options: []option{EncodeBytes{Value: ByteEncodingRaw}}, Encoded: "hi"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			var decoded Decoded
+			var encoded Encoded
+
+			// Act
+			decodeErr := CopyMatching(Encoded{Value: testData.Encoded}, &decoded, testData.Options...)
+			encodeErr := CopyMatching(Decoded{Value: []byte("hi")}, &encoded, testData.Options...)
+
+			// Assert
+			assert.NoError(t, decodeErr)
+			assert.NoError(t, encodeErr)
+			assert.Equal(t, []byte("hi"), decoded.Value)
+			assert.Equal(t, testData.Encoded, encoded.Value)
+		})
+	}
+}
+
+func TestCopyMatching_MatchFields(t *testing.T) {
+	type Src struct {
+		FullName string `json:"full_name"`
+		Email    string `json:"EMAIL"`
+		Age      int    `json:"years"`
+	}
+	type Dst struct {
+		FullName string `json:"name"`
+		Email    string `json:"email"`
+		Age      int    `json:"years"`
+	}
+
+	tests := map[string]struct {
+		Options  []option
+		Expected Dst
+	}{
+		"default":       {Options: nil, Expected: Dst{Age: 42}},
+		"MatchJsonName": {Options: []option{MatchFields{Value: MatchJsonName}}, Expected: Dst{Age: 42}},
+		"MatchGoName": {
+			Options:  []option{MatchFields{Value: MatchGoName}},
+			Expected: Dst{FullName: "alice", Email: "alice@example.com", Age: 42},
+		},
+		"MatchLoose": {
+			Options:  []option{MatchFields{Value: MatchLoose}},
+			Expected: Dst{FullName: "alice", Email: "alice@example.com", Age: 42},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			var dst Dst
+
+			// Act
+			err := CopyMatching(Src{FullName: "alice", Email: "alice@example.com", Age: 42}, &dst, testData.Options...)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, dst)
+		})
+	}
+}
+
+func TestCopyMatching_MatchLoose_CaseInsensitive(t *testing.T) {
+	// Arrange
+	src := struct {
+		UserID string `json:"userId"`
+	}{UserID: "1"}
+	var dst struct {
+		ID string `json:"userid"`
+	}
+
+	// Act
+	err := CopyMatching(src, &dst, MatchFields{Value: MatchLoose})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1", dst.ID)
+}
+
+func TestCopyMatching_JsonNumbers(t *testing.T) {
+	// Arrange
+	type Payment struct {
+		ID       int64   `json:"id"`
+		Amount   float64 `json:"amount"`
+		Priority int8    `json:"priority"`
+		Count    uint    `json:"count"`
+		Data     []byte  `json:"data"`
+	}
+
+	tests := map[string]struct {
+		Payload      string
+		Expected     Payment
+		ErrorMessage string
+	}{
+		"large id": {
+			Payload:  `{"id": 9007199254740993, "amount": 10.25, "data": "aGk="}`,
+			Expected: Payment{ID: 9007199254740993, Amount: 10.25, Data: []byte("hi")},
+		},
+		"overflow": {
+			Payload:      `{"priority": 300}`,
+			ErrorMessage: `nullify: Priority: strconv.ParseInt: parsing "300": value out of range`,
+		},
+		"negative unsigned": {
+			Payload:      `{"count": -1}`,
+			ErrorMessage: `nullify: Count: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			options := append(slices.Clip(JsonOptions), JsonNumbers{Value: true})
+			p := Nullify(Payment{}, options...)
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+			var payment Payment
+
+			// Act
+			err := CopyMatching(p, &payment, options...)
+
+			// Assert
+			if testData.ErrorMessage != "" {
+				assert.EqualError(t, err, testData.ErrorMessage)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, payment)
+		})
+	}
+}
+
+func TestCopyMatching_JsonNumbersFromOriginal(t *testing.T) {
+	// Arrange
+	type Payment struct {
+		ID     int64   `json:"id"`
+		Amount float64 `json:"amount"`
+	}
+	p := Nullify(Payment{}, JsonNumbers{Value: true})
+
+	// Act
+	err := CopyMatching(&Payment{ID: 9007199254740993, Amount: 0.1}, p)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"id": json.Number("9007199254740993"), "amount": json.Number("0.1")}, Flatten(p))
+}
+
+func TestCopyMatching_TextLeaves(t *testing.T) {
+	// Arrange
+	type Account struct {
+		Balance big.Int `json:"balance"`
+	}
+	p := Nullify(Account{}, WithTypeOverride(reflect.TypeOf(big.Int{}), reflect.TypeOf((*string)(nil))))
+	var account Account
+	original := Account{}
+	original.Balance.SetString("-98765432109876543210", 10)
+
+	// Act
+	err := CopyMatching(&original, p)
+	data, errMarshal := json.Marshal(p)
+	errBack := CopyMatching(p, &account)
+	errInvalid := CopyMatching(struct {
+		Balance string `json:"balance"`
+	}{Balance: "ten"}, &Account{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, errMarshal)
+	assert.JSONEq(t, `{"balance": "-98765432109876543210"}`, string(data))
+	assert.NoError(t, errBack)
+	assert.Equal(t, "-98765432109876543210", account.Balance.String())
+	assert.EqualError(t, errInvalid, `nullify: Balance: math/big: cannot unmarshal "ten" into a *big.Int`)
+}
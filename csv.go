@@ -0,0 +1,192 @@
+package nullify
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CSVDecoder decodes the records of a CSV document (RFC 4180) into instances of the nullified type of a prototype.
+// Columns are matched to fields by the name in the `csv` tag, falling back to the Go field name. Unquoted empty
+// cells and missing trailing cells leave the field nil, while quoted empty cells ("") set it, such that an absent
+// value can be distinguished from an empty one. Cells are parsed according to the type of the field like the
+// `default` tags of ApplyDefaults. A leading UTF-8 byte order mark is skipped.
+type CSVDecoder struct {
+	r       *bufio.Reader
+	typ     reflect.Type
+	columns []int // field index of the nullified struct per column, -1 if the column is ignored
+	header  []string
+	line    int
+}
+
+// csvCell is a single cell of a CSV record
+type csvCell struct {
+	value  string
+	quoted bool
+	line   int // line the cell starts on, like csv.Reader.FieldPos
+}
+
+// NewCSVDecoder reads the header of the CSV document from r and returns a CSVDecoder for the remaining records.
+// The prototype must be a struct (or pointer to struct), options are passed to Nullify.
+func NewCSVDecoder(r io.Reader, prototype any, options ...option) (*CSVDecoder, error) {
+	typ := reflect.TypeOf(newNullified(prototype, options...))
+	if typ == nil || typ.Kind() != reflect.Pointer || !isNullifiedStruct(typ.Elem()) {
+		return nil, fmt.Errorf("nullify: prototype must be a struct, got %T", prototype)
+	}
+
+	d := &CSVDecoder{r: bufio.NewReader(r), typ: typ.Elem()}
+	if bom, _ := d.r.Peek(3); string(bom) == "\uFEFF" {
+		_, _ = d.r.Discard(3)
+	}
+	header, err := d.readRecord()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("nullify: csv: missing header")
+		}
+		return nil, err
+	}
+
+	fields := make(map[string]int, d.typ.NumField())
+	for i := 0; i < d.typ.NumField(); i++ {
+		if name, ok := csvName(d.typ.Field(i)); ok && d.typ.Field(i).IsExported() {
+			fields[name] = i
+		}
+	}
+
+	d.columns = make([]int, len(header))
+	d.header = make([]string, len(header))
+	for i, cell := range header {
+		d.header[i] = cell.value
+		if index, ok := fields[cell.value]; ok {
+			d.columns[i] = index
+		} else {
+			d.columns[i] = -1
+		}
+	}
+	return d, nil
+}
+
+// Header returns the column names of the CSV document
+func (d *CSVDecoder) Header() []string {
+	return d.header
+}
+
+// Decode returns the next record as an instance of the nullified type, or io.EOF if there are no more records
+func (d *CSVDecoder) Decode() (any, error) {
+	record, err := d.readRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := reflect.New(d.typ)
+	for i, cell := range record {
+		if i >= len(d.columns) || d.columns[i] < 0 || cell.value == "" && !cell.quoted {
+			continue
+		}
+
+		field := instance.Elem().Field(d.columns[i])
+		if err := parseText(field, cell.value); err != nil {
+			return nil, fmt.Errorf("nullify: csv line %d: %s: %w", cell.line, d.header[i], err)
+		}
+	}
+	return instance.Interface(), nil
+}
+
+// DecodeCSV decodes all records of the CSV document in r, see CSVDecoder
+func DecodeCSV(r io.Reader, prototype any, options ...option) ([]any, error) {
+	d, err := NewCSVDecoder(r, prototype, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []any
+	for {
+		record, err := d.Decode()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// readRecord reads the cells of the next record, skipping empty lines. It returns io.EOF if there are no more
+// records.
+func (d *CSVDecoder) readRecord() ([]csvCell, error) {
+	var cells []csvCell
+	var cell strings.Builder
+	quoted, inQuotes, started := false, false, false
+	line := d.line + 1 // line the current cell starts on
+	for {
+		r, _, err := d.r.ReadRune()
+		if errors.Is(err, io.EOF) {
+			if inQuotes {
+				return nil, fmt.Errorf("nullify: csv line %d: unterminated quoted cell", line)
+			}
+			if !started {
+				return nil, io.EOF
+			}
+			d.line++
+			return append(cells, csvCell{value: cell.String(), quoted: quoted, line: line}), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case inQuotes && r == '"':
+			if next, _ := d.r.Peek(1); len(next) == 1 && next[0] == '"' {
+				_, _ = d.r.ReadByte()
+				cell.WriteRune('"')
+			} else {
+				inQuotes = false
+			}
+		case inQuotes:
+			if r == '\n' {
+				d.line++
+			}
+			cell.WriteRune(r)
+		case r == '\r':
+			if next, _ := d.r.Peek(1); len(next) == 1 && next[0] == '\n' {
+				continue
+			}
+			cell.WriteRune(r)
+		case r == '\n':
+			d.line++
+			if !started {
+				line = d.line + 1
+				continue // empty line
+			}
+			return append(cells, csvCell{value: cell.String(), quoted: quoted, line: line}), nil
+		case r == '"' && cell.Len() == 0 && !quoted:
+			quoted, inQuotes = true, true
+		case r == ',':
+			cells = append(cells, csvCell{value: cell.String(), quoted: quoted, line: line})
+			cell.Reset()
+			quoted, line = false, d.line+1
+		default:
+			cell.WriteRune(r)
+		}
+		started = true
+	}
+}
+
+// csvName returns the column name of field from the `csv` tag or the Go field name, false if the field is ignored
+// (`csv:"-"`)
+func csvName(field reflect.StructField) (string, bool) {
+	value := field.Tag.Get("csv")
+	if value == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
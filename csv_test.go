@@ -0,0 +1,185 @@
+package nullify
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	type Person struct {
+		Name    string  `csv:"name"`
+		Age     int     `csv:"age"`
+		Note    string  `csv:"note"`
+		Score   float64 `csv:"score"`
+		Active  bool
+		Ignored string `csv:"-"`
+	}
+
+	tests := map[string]struct {
+		Document string
+		Expected []Person
+		Set      [][]string
+	}{
+		"plain": {
+			Document: "name,age,note\nalice,30,hello\n",
+			Expected: []Person{{Name: "alice", Age: 30, Note: "hello"}},
+			Set:      [][]string{{"Name", "Age", "Note"}},
+		},
+		"empty and quoted empty": {
+			Document: "name,age,note\r\nalice,,\"\"\r\n",
+			Expected: []Person{{Name: "alice"}},
+			Set:      [][]string{{"Name", "Note"}},
+		},
+		"quoted": {
+			Document: "name,note,Ignored\n\"bob, jr.\",\"said \"\"hi\"\"\nand left\",x",
+			Expected: []Person{{Name: "bob, jr.", Note: "said \"hi\"\nand left"}},
+			Set:      [][]string{{"Name", "Note"}},
+		},
+		"byte order mark": {
+			Document: "\uFEFFname,age\nalice,30\n",
+			Expected: []Person{{Name: "alice", Age: 30}},
+			Set:      [][]string{{"Name", "Age"}},
+		},
+		"missing trailing cells and empty lines": {
+			Document: "name,score,Active,unknown\n\nalice,1.5\n\nbob,,true,x\n",
+			Expected: []Person{{Name: "alice", Score: 1.5}, {Name: "bob", Active: true}},
+			Set:      [][]string{{"Name", "Score"}, {"Name", "Active"}},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			records, err := DecodeCSV(strings.NewReader(testData.Document), Person{})
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Len(t, records, len(testData.Expected))
+			for i, record := range records {
+				var person Person
+				assert.Nil(t, CopyMatching(record, &person))
+				assert.Equal(t, testData.Expected[i], person)
+
+				var set []string
+				for name, value := range plainOf(record) {
+					if value != nil {
+						set = append(set, name)
+					}
+				}
+				assert.ElementsMatch(t, testData.Set[i], set)
+			}
+		})
+	}
+}
+
+func TestDecodeCSV_ValueResult(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string  `csv:"name"`
+		Age     int     `csv:"age"`
+		Note    string  `csv:"note"`
+		Score   float64 `csv:"score"`
+		Active  bool
+		Ignored string `csv:"-"`
+	}
+
+	// Act
+	records, err := DecodeCSV(strings.NewReader("name\nalice\n"), Person{}, ValueResult{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"Name": "alice"}}, []any{plainOf(records[0])})
+}
+
+func TestDecodeCSV_Errors(t *testing.T) {
+	type Person struct {
+		Name    string  `csv:"name"`
+		Age     int     `csv:"age"`
+		Note    string  `csv:"note"`
+		Score   float64 `csv:"score"`
+		Active  bool
+		Ignored string `csv:"-"`
+	}
+
+	tests := map[string]struct {
+		Document     string
+		Prototype    any
+		ErrorMessage string
+	}{
+		"invalid value": {
+			Document:     "name,age\nalice,30\nbob,old\n",
+			Prototype:    Person{},
+			ErrorMessage: `nullify: csv line 3: age: strconv.ParseInt: parsing "old": invalid syntax`,
+		},
+		"invalid value before multiline cell": {
+			Document:     "name,age,note\nalice,old,\"a\nb\"\n",
+			Prototype:    Person{},
+			ErrorMessage: `nullify: csv line 2: age: strconv.ParseInt: parsing "old": invalid syntax`,
+		},
+		"invalid value after multiline cell": {
+			Document:     "name,note,age\nalice,\"a\nb\",old\n",
+			Prototype:    Person{},
+			ErrorMessage: `nullify: csv line 3: age: strconv.ParseInt: parsing "old": invalid syntax`,
+		},
+		"unterminated": {
+			Document:     "name\n\"alice\n",
+			Prototype:    Person{},
+			ErrorMessage: "nullify: csv line 2: unterminated quoted cell",
+		},
+		"missing header": {
+			Document:     "",
+			Prototype:    Person{},
+			ErrorMessage: "nullify: csv: missing header",
+		},
+		"not a struct": {
+			Document:     "name\n",
+			Prototype:    "",
+			ErrorMessage: "nullify: prototype must be a struct, got string",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := DecodeCSV(strings.NewReader(testData.Document), testData.Prototype)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestCSVDecoder(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string  `csv:"name"`
+		Age     int     `csv:"age"`
+		Note    string  `csv:"note"`
+		Score   float64 `csv:"score"`
+		Active  bool
+		Ignored string `csv:"-"`
+	}
+
+	d, err := NewCSVDecoder(strings.NewReader("name,age\nalice,30"), Person{})
+	assert.Nil(t, err)
+
+	// Act
+	first, firstErr := d.Decode()
+	_, secondErr := d.Decode()
+
+	// Assert
+	assert.Equal(t, []string{"name", "age"}, d.Header())
+	assert.Nil(t, firstErr)
+	assert.Equal(t, map[string]any{"Name": "alice", "Age": 30}, plainOf(first))
+	assert.True(t, errors.Is(secondErr, io.EOF))
+}
+
+// plainOf returns the set fields of the nullified struct v by Go field name
+func plainOf(v any) map[string]any {
+	value, _ := plain(reflect.ValueOf(v))
+	return value.(map[string]any)
+}
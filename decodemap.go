@@ -0,0 +1,178 @@
+package nullify
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// DecodeMap decodes input (e.g. the settings of Viper or a document of Consul) into a new instance of the nullified
+// type of prototype and returns it, leaving the fields of absent keys nil. Keys are matched to fields by the name in
+// the `mapstructure` tag, falling back to the Go field name case-insensitively like mapstructure does. Nested maps
+// with string or any keys decode into nested structs, numbers convert between kinds if they fit the field (without
+// overflowing, losing their sign or their fraction) and strings are parsed according to the type of the field (like
+// the `default` tags of ApplyDefaults), as configuration from environment variables is often a string.
+func DecodeMap(input map[string]any, prototype any, options ...option) (any, error) {
+	instance := reflect.ValueOf(newNullified(prototype, options...))
+	if instance.Kind() != reflect.Pointer || !isNullifiedStruct(instance.Type().Elem()) {
+		return nil, fmt.Errorf("nullify: prototype must be a struct, got %T", prototype)
+	}
+
+	if err := decodeMapValue(instance, reflect.ValueOf(input), ""); err != nil {
+		return nil, err
+	}
+	return instance.Interface(), nil
+}
+
+// decodeMapValue decodes src into dst, allocating pointers as needed. Nil values in src leave dst untouched.
+func decodeMapValue(dst reflect.Value, src reflect.Value, path string) error {
+	for src.Kind() == reflect.Interface || src.Kind() == reflect.Pointer {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeMapValue(dst.Elem(), src, path)
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Map:
+		return decodeMapStruct(dst, src, path)
+	case dst.Kind() == reflect.Slice && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeMapValue(slice.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := decodeMapValue(key, iter.Key(), path); err != nil {
+				return err
+			}
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeMapValue(elem, iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		dst.Set(m)
+	case src.Kind() == reflect.String && dst.Kind() != reflect.String:
+		if err := parseText(dst, src.String()); err != nil {
+			return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+		}
+	case isNumber(src.Kind()) && isNumber(dst.Kind()):
+		if !fitsNumber(src, dst.Type()) {
+			return fmt.Errorf("nullify: %s: %v does not fit into %s", pathOrRoot(path), src, dst.Type())
+		}
+		dst.Set(src.Convert(dst.Type()))
+	case src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("nullify: %s: cannot decode %s into %s", pathOrRoot(path), src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// decodeMapStruct decodes the entries of the map src into the fields of the struct dst
+func decodeMapStruct(dst reflect.Value, src reflect.Value, path string) error {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		name, ok := mapstructureName(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		value, ok := mapEntry(src, name)
+		if !ok {
+			continue
+		}
+		if err := decodeMapValue(dst.Field(i), value, joinPath(path, field.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapEntry returns the value of the key of m equal to name, falling back to a case-insensitive match
+func mapEntry(m reflect.Value, name string) (reflect.Value, bool) {
+	var fallback reflect.Value
+	iter := m.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		for key.Kind() == reflect.Interface && !key.IsNil() {
+			key = key.Elem()
+		}
+		if key.Kind() != reflect.String {
+			continue
+		}
+		if key.String() == name {
+			return iter.Value(), true
+		}
+		if !fallback.IsValid() && strings.EqualFold(key.String(), name) {
+			fallback = iter.Value()
+		}
+	}
+	return fallback, fallback.IsValid()
+}
+
+// mapstructureName returns the key of field from the `mapstructure` tag or the Go field name, false if the field is
+// ignored (`mapstructure:"-"`)
+func mapstructureName(field reflect.StructField) (string, bool) {
+	value := field.Tag.Get("mapstructure")
+	if value == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
+
+// fitsNumber returns true if the number src converts to the number type t without overflowing it, losing its sign
+// or losing its fraction
+func fitsNumber(src reflect.Value, t reflect.Type) bool {
+	dst := reflect.New(t).Elem()
+	switch {
+	case src.CanInt() && dst.CanInt():
+		return !dst.OverflowInt(src.Int())
+	case src.CanInt() && dst.CanUint():
+		return src.Int() >= 0 && !dst.OverflowUint(uint64(src.Int()))
+	case src.CanUint() && dst.CanInt():
+		return src.Uint() <= math.MaxInt64 && !dst.OverflowInt(int64(src.Uint()))
+	case src.CanUint() && dst.CanUint():
+		return !dst.OverflowUint(src.Uint())
+	case src.CanFloat() && dst.CanInt():
+		f := src.Float()
+		return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !dst.OverflowInt(int64(f))
+	case src.CanFloat() && dst.CanUint():
+		f := src.Float()
+		return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !dst.OverflowUint(uint64(f))
+	case src.CanFloat():
+		return !dst.OverflowFloat(src.Float())
+	default: // integers into floats, which may round but cannot overflow
+		return true
+	}
+}
+
+// isNumber returns true for integer and floating point kinds
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
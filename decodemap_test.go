@@ -0,0 +1,192 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDecodeMap(t *testing.T) {
+	type Server struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	}
+	type Settings struct {
+		Name    string
+		Debug   bool              `mapstructure:"debug"`
+		Timeout time.Duration     `mapstructure:"timeout"`
+		Ratio   float64           `mapstructure:"ratio"`
+		Tags    []string          `mapstructure:"tags"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Server  Server            `mapstructure:"server"`
+		Ignored string            `mapstructure:"-"`
+	}
+
+	tests := map[string]struct {
+		Input    map[string]any
+		Expected map[string]any
+	}{
+		"empty": {
+			Input:    map[string]any{},
+			Expected: map[string]any{},
+		},
+		"values": {
+			Input: map[string]any{
+				"name":    "app",
+				"debug":   false,
+				"timeout": "5s",
+				"ratio":   1,
+				"tags":    []any{"a", "b"},
+				"labels":  map[string]any{"k": "v"},
+				"server":  map[any]any{"host": "localhost", "port": "8080"},
+				"Ignored": "x",
+			},
+			Expected: map[string]any{
+				"Name":    "app",
+				"Debug":   false,
+				"Timeout": 5 * time.Second,
+				"Ratio":   1.0,
+				"Tags":    []any{"a", "b"},
+				"Labels":  map[any]any{"k": "v"},
+				"Server":  map[string]any{"Host": "localhost", "Port": 8080},
+			},
+		},
+		"nil and partial": {
+			Input:    map[string]any{"debug": nil, "server": map[string]any{"port": 9090.0}},
+			Expected: map[string]any{"Server": map[string]any{"Port": 9090}},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p, err := DecodeMap(testData.Input, Settings{})
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testData.Expected, plainOf(p))
+		})
+	}
+}
+
+func TestDecodeMap_ValueResult(t *testing.T) {
+	// Arrange
+	type Server struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	}
+	type Settings struct {
+		Name    string
+		Debug   bool              `mapstructure:"debug"`
+		Timeout time.Duration     `mapstructure:"timeout"`
+		Ratio   float64           `mapstructure:"ratio"`
+		Tags    []string          `mapstructure:"tags"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Server  Server            `mapstructure:"server"`
+		Ignored string            `mapstructure:"-"`
+	}
+
+	// Act
+	p, err := DecodeMap(map[string]any{"name": "app"}, Settings{}, ValueResult{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"Name": "app"}, plainOf(p))
+}
+
+func TestDecodeMap_Numbers(t *testing.T) {
+	// Arrange
+	type Limits struct {
+		Count uint    `mapstructure:"count"`
+		Level int8    `mapstructure:"level"`
+		Ratio float32 `mapstructure:"ratio"`
+	}
+
+	// Act
+	instance, err := DecodeMap(map[string]any{"count": 3.0, "level": uint8(7), "ratio": 0.5}, Limits{})
+
+	// Assert
+	assert.NoError(t, err)
+	var limits Limits
+	assert.NoError(t, CopyMatching(instance, &limits, MatchFields{Value: MatchGoName}))
+	assert.Equal(t, Limits{Count: 3, Level: 7, Ratio: 0.5}, limits)
+}
+
+func TestDecodeMap_Errors(t *testing.T) {
+	type Server struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	}
+	type Settings struct {
+		Name    string
+		Debug   bool              `mapstructure:"debug"`
+		Timeout time.Duration     `mapstructure:"timeout"`
+		Ratio   float64           `mapstructure:"ratio"`
+		Tags    []string          `mapstructure:"tags"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Server  Server            `mapstructure:"server"`
+		Ignored string            `mapstructure:"-"`
+	}
+	type Limits struct {
+		Count uint    `mapstructure:"count"`
+		Level int8    `mapstructure:"level"`
+		Ratio float32 `mapstructure:"ratio"`
+	}
+
+	tests := map[string]struct {
+		Input        map[string]any
+		Prototype    any
+		ErrorMessage string
+	}{
+		"invalid string": {
+			Input:        map[string]any{"server": map[string]any{"port": "http"}},
+			Prototype:    Settings{},
+			ErrorMessage: `nullify: Server.Port: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+		"incompatible": {
+			Input:        map[string]any{"tags": true},
+			Prototype:    Settings{},
+			ErrorMessage: "nullify: Tags: cannot decode bool into []*string",
+		},
+		"negative into unsigned": {
+			Input:        map[string]any{"count": -1},
+			Prototype:    Limits{},
+			ErrorMessage: "nullify: Count: -1 does not fit into uint",
+		},
+		"integer overflow": {
+			Input:        map[string]any{"level": 300},
+			Prototype:    Limits{},
+			ErrorMessage: "nullify: Level: 300 does not fit into int8",
+		},
+		"unsigned overflow": {
+			Input:        map[string]any{"level": uint64(1 << 63)},
+			Prototype:    Limits{},
+			ErrorMessage: "nullify: Level: 9223372036854775808 does not fit into int8",
+		},
+		"fraction": {
+			Input:        map[string]any{"count": 1.5},
+			Prototype:    Limits{},
+			ErrorMessage: "nullify: Count: 1.5 does not fit into uint",
+		},
+		"float overflow": {
+			Input:        map[string]any{"ratio": 1e300},
+			Prototype:    Limits{},
+			ErrorMessage: "nullify: Ratio: 1e+300 does not fit into float32",
+		},
+		"not a struct": {
+			Input:        map[string]any{},
+			Prototype:    "",
+			ErrorMessage: "nullify: prototype must be a struct, got string",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := DecodeMap(testData.Input, testData.Prototype)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package nullify
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults sets the unset fields of the nullified value to the value of the `default` tag of the corresponding
+// field in prototype (the struct that was nullified). Values are parsed according to their type: strings as is,
+// booleans and numbers with strconv, time.Duration with time.ParseDuration, encoding.TextUnmarshaler with
+// UnmarshalText and any other type (e.g. slices) as json. Unset nested structs are only allocated if a default was
+// applied to one of their fields.
+func ApplyDefaults(nullified any, prototype any) error {
+	dst := reflect.ValueOf(nullified)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() || !isNullifiedStruct(dst.Type().Elem()) {
+		return fmt.Errorf("nullify: nullified must be a non-nil pointer to a nullified struct, got %T", nullified)
+	}
+
+	protoType := reflect.TypeOf(prototype)
+	for protoType != nil && protoType.Kind() == reflect.Pointer {
+		protoType = protoType.Elem()
+	}
+	if protoType == nil || protoType.Kind() != reflect.Struct {
+		return fmt.Errorf("nullify: prototype must be a struct, got %T", prototype)
+	}
+
+	_, err := applyDefaults(dst.Elem(), protoType, "")
+	return err
+}
+
+// applyDefaults applies the defaults of protoType to the unset fields of dst, returning true if any were applied
+func applyDefaults(dst reflect.Value, protoType reflect.Type, path string) (bool, error) {
+	applied := false
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		protoField, ok := protoType.FieldByName(field.Name)
+		if !field.IsExported() || !ok {
+			continue
+		}
+
+		fieldPath := joinPath(path, field.Name)
+		value := dst.Field(i)
+		if def, ok := protoField.Tag.Lookup("default"); ok {
+			if !isNil(value) {
+				continue
+			}
+			if err := parseText(value, def); err != nil {
+				return false, fmt.Errorf("nullify: %s: invalid default %q: %w", fieldPath, def, err)
+			}
+			applied = true
+			continue
+		}
+
+		nestedType := protoField.Type
+		for nestedType.Kind() == reflect.Pointer {
+			nestedType = nestedType.Elem()
+		}
+		if value.Kind() != reflect.Pointer || !isNullifiedStruct(value.Type().Elem()) || nestedType.Kind() != reflect.Struct {
+			continue
+		}
+
+		nested := value
+		if value.IsNil() {
+			nested = reflect.New(value.Type().Elem())
+		}
+		ok, err := applyDefaults(nested.Elem(), nestedType, fieldPath)
+		if err != nil {
+			return false, err
+		}
+		if ok && value.IsNil() {
+			value.Set(nested)
+		}
+		applied = applied || ok
+	}
+	return applied, nil
+}
@@ -0,0 +1,129 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type Server struct {
+		Host string `json:"host" default:"localhost"`
+		Port uint16 `json:"port" default:"8080"`
+	}
+	type Settings struct {
+		Name    string        `json:"name"`
+		Debug   bool          `json:"debug" default:"true"`
+		Retries int           `json:"retries" default:"3"`
+		Ratio   float64       `json:"ratio" default:"0.5"`
+		Timeout time.Duration `json:"timeout" default:"5s"`
+		Since   time.Time     `json:"since" default:"2024-01-02T03:04:05Z"`
+		Tags    []string      `json:"tags" default:"[\"a\", \"b\"]"`
+		Server  Server        `json:"server"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected Settings
+	}{
+		"empty": {
+			Payload: `{}`,
+			Expected: Settings{
+				Debug:   true,
+				Retries: 3,
+				Ratio:   0.5,
+				Timeout: 5 * time.Second,
+				Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Tags:    []string{"a", "b"},
+				Server:  Server{Host: "localhost", Port: 8080},
+			},
+		},
+		"set values are kept": {
+			Payload: `{"name": "app", "debug": false, "retries": 0, "tags": [], "server": {"host": "example.com"}}`,
+			Expected: Settings{
+				Name:    "app",
+				Ratio:   0.5,
+				Timeout: 5 * time.Second,
+				Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Tags:    []string{},
+				Server:  Server{Host: "example.com", Port: 8080},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Settings{}, JsonOptions...)
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			err := ApplyDefaults(p, Settings{})
+
+			// Assert
+			assert.Nil(t, err)
+			var actual Settings
+			assert.Nil(t, CopyMatching(p, &actual))
+			assert.Equal(t, testData.Expected, actual)
+		})
+	}
+}
+
+func TestApplyDefaults_NestedWithoutDefaults(t *testing.T) {
+	// Arrange
+	type inner struct {
+		Value string
+	}
+	type outer struct {
+		Inner inner
+	}
+	p := Nullify(outer{})
+
+	// Act
+	err := ApplyDefaults(p, outer{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Nullify(outer{}), p)
+}
+
+func TestApplyDefaults_Errors(t *testing.T) {
+	type invalid struct {
+		Count int `default:"many"`
+	}
+
+	tests := map[string]struct {
+		Nullified    any
+		Prototype    any
+		ErrorMessage string
+	}{
+		"invalid default": {
+			Nullified:    Nullify(invalid{}),
+			Prototype:    invalid{},
+			ErrorMessage: `nullify: Count: invalid default "many": strconv.ParseInt: parsing "many": invalid syntax`,
+		},
+		"not nullified": {
+			Nullified:    &invalid{},
+			Prototype:    invalid{},
+			ErrorMessage: "nullify: nullified must be a non-nil pointer to a nullified struct, got *nullify.invalid",
+		},
+		"prototype not a struct": {
+			Nullified:    Nullify(invalid{}),
+			Prototype:    "",
+			ErrorMessage: "nullify: prototype must be a struct, got string",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := ApplyDefaults(testData.Nullified, testData.Prototype)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
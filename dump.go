@@ -0,0 +1,90 @@
+package nullify
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Dump returns a readable representation of a nullified value with one line per field, distinguishing set fields
+// from unset (nil) ones, e.g.
+//
+//	Name: "bob"
+//	Age: <unset>
+//	Address.City: "Springfield"
+//
+// Nested structs are expanded into dotted paths of Go field names, slices and maps are printed inline.
+func Dump(nullified any) string {
+	v, ok := indirect(reflect.ValueOf(nullified))
+	if !ok {
+		return "<unset>"
+	}
+	if v.Kind() != reflect.Struct {
+		return dumpValue(v)
+	}
+
+	var lines []string
+	dumpStruct(&lines, v, "")
+	return strings.Join(lines, "\n")
+}
+
+// dumpStruct appends a line for every field of the struct value v to lines
+func dumpStruct(lines *[]string, v reflect.Value, path string) {
+	for i := 0; i < v.NumField(); i++ {
+		fieldPath := joinPath(path, v.Type().Field(i).Name)
+		value, ok := indirect(v.Field(i))
+		switch {
+		case !ok:
+			*lines = append(*lines, fieldPath+": <unset>")
+		case isNullifiedStruct(value.Type()) && value.NumField() > 0:
+			dumpStruct(lines, value, fieldPath)
+		default:
+			*lines = append(*lines, fieldPath+": "+dumpValue(value))
+		}
+	}
+}
+
+// dumpValue returns the inline representation of v
+func dumpValue(v reflect.Value) string {
+	v, ok := indirect(v)
+	if !ok {
+		return "<unset>"
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !isNullifiedStruct(v.Type()) {
+			break
+		}
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = v.Type().Field(i).Name + ": " + dumpValue(v.Field(i))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = dumpValue(v.Index(i))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case reflect.Map:
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, dumpValue(iter.Key())+": "+dumpValue(iter.Value()))
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	}
+
+	if !v.CanInterface() {
+		return fmt.Sprintf("<%s>", v.Type())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,53 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string
+		City   string
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Tags    []string
+		Labels  map[string]int
+		Address Address
+		Friends []Address
+		Parent  *Address
+	}
+
+	p := Nullify(Person{})
+	err := json.Unmarshal([]byte(`{
+		"Name": "bob",
+		"Tags": ["a", null],
+		"Labels": {"y": 2, "x": 1},
+		"Address": {"City": "Springfield"},
+		"Friends": [{"Street": "Main St"}]
+	}`), p)
+	assert.Nil(t, err)
+
+	// Act
+	output := Dump(p)
+
+	// Assert
+	assert.Equal(t, `Name: "bob"
+Age: <unset>
+Tags: ["a", <unset>]
+Labels: {"x": 1, "y": 2}
+Address.Street: <unset>
+Address.City: "Springfield"
+Friends: [{Street: "Main St", City: <unset>}]
+Parent: <unset>`, output)
+}
+
+func TestDump_NonStruct(t *testing.T) {
+	assert.Equal(t, "<unset>", Dump(nil))
+	assert.Equal(t, `""`, Dump(Nullify("")))
+	assert.Equal(t, "[1, 2]", Dump([]int{1, 2}))
+}
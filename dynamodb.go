@@ -0,0 +1,82 @@
+package nullify
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DynamoUpdate is a DynamoDB UpdateExpression with its placeholders, for the UpdateExpression,
+// ExpressionAttributeNames and ExpressionAttributeValues of an UpdateItem request. Values still have to be
+// converted to attribute values, e.g. with attributevalue.MarshalMap.
+type DynamoUpdate struct {
+	Expression string            // e.g. SET #n0 = :v0, #n1.#n2 = :v1, empty if no field is set
+	Names      map[string]string // expression attribute names, e.g. #n0 -> name
+	Values     map[string]any    // expression attribute values, e.g. :v0 -> alice
+}
+
+// DynamoUpdateExpression returns the SET UpdateExpression for the fields of the nullified value that are set
+// (non-nil), with the values dereferenced. All attribute names are placeholders such that reserved words are safe.
+// Nested structs are addressed with document paths (`#n1.#n2`) such that only the provided subfields are updated.
+//
+// Attribute names follow the AWS SDK: the name from the `dynamodbav` tag or the Go field name. Fields tagged
+// `dynamodbav:"-"` are skipped and embedded structs without a name are flattened into their parent. WithTagPriority
+// takes precedence over the `dynamodbav` tag for naming fields.
+func DynamoUpdateExpression(nullified any, options ...option) DynamoUpdate {
+	update := DynamoUpdate{Names: map[string]string{}, Values: map[string]any{}}
+	var assignments []string
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && isNullifiedStruct(v.Type()) {
+		dynamoUpdate(&update, &assignments, map[string]string{}, v, "", newConfig(options...))
+	}
+	if len(assignments) > 0 {
+		update.Expression = "SET " + strings.Join(assignments, ", ")
+	}
+	return update
+}
+
+// dynamoUpdate appends an assignment for each set field of the struct value v, prefixing the document path with
+// path. placeholders holds the placeholder of each attribute name that is already used.
+func dynamoUpdate(
+	update *DynamoUpdate, assignments *[]string, placeholders map[string]string, v reflect.Value, path string, cfg config,
+) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("dynamodbav"), ",")
+		if priority, found := priorityName(field, cfg); found {
+			name = priority
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		if name == "" && field.Anonymous && isNullifiedStruct(value.Type()) {
+			// attributevalue flattens embedded structs without a name into their parent
+			dynamoUpdate(update, assignments, placeholders, value, path, cfg)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		placeholder, ok := placeholders[name]
+		if !ok {
+			placeholder = "#n" + strconv.Itoa(len(placeholders))
+			placeholders[name] = placeholder
+			update.Names[placeholder] = name
+		}
+
+		if isNullifiedStruct(value.Type()) {
+			dynamoUpdate(update, assignments, placeholders, value, path+placeholder+".", cfg)
+			continue
+		}
+
+		valuePlaceholder := ":v" + strconv.Itoa(len(update.Values))
+		update.Values[valuePlaceholder] = value.Interface()
+		*assignments = append(*assignments, path+placeholder+" = "+valuePlaceholder)
+	}
+}
@@ -0,0 +1,66 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDynamoUpdateExpression(t *testing.T) {
+	type Base struct {
+		ID string `dynamodbav:"id" json:"id"`
+	}
+	type Address struct {
+		Name string `dynamodbav:"name"`
+		City string `dynamodbav:"city"`
+	}
+	type Person struct {
+		Name    string  `dynamodbav:"name" json:"name"`
+		Age     int     `json:"age"`
+		Address Address `dynamodbav:"address" json:"address"`
+		Secret  string  `dynamodbav:"-" json:"secret"`
+		Base
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected DynamoUpdate
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: DynamoUpdate{Names: map[string]string{}, Values: map[string]any{}},
+		},
+		"set fields": {
+			Payload: `{"name": "alice", "age": 0, "address": {"Name": "home", "City": "Springfield"}, "secret": "x"}`,
+			Expected: DynamoUpdate{
+				Expression: "SET #n0 = :v0, #n1 = :v1, #n2.#n0 = :v2, #n2.#n3 = :v3",
+				Names:      map[string]string{"#n0": "name", "#n1": "Age", "#n2": "address", "#n3": "city"},
+				Values:     map[string]any{":v0": "alice", ":v1": 0, ":v2": "home", ":v3": "Springfield"},
+			},
+		},
+		"embedded": {
+			Payload: `{"name": "alice", "id": "x"}`,
+			Expected: DynamoUpdate{
+				Expression: "SET #n0 = :v0, #n1 = :v1",
+				Names:      map[string]string{"#n0": "name", "#n1": "id"},
+				Values:     map[string]any{":v0": "alice", ":v1": "x"},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			update := DynamoUpdateExpression(p)
+
+			// Assert
+			assert.Equal(t, testData.Expected, update)
+		})
+	}
+}
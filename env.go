@@ -0,0 +1,111 @@
+package nullify
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// DecodeEnv fills a new instance of the nullified type of prototype from environment variables and returns it.
+// Fields are named by the `env` tag or the upper snake case of the Go field name (e.g. MaxConns becomes MAX_CONNS),
+// prefixed with prefix and an underscore unless prefix is empty. Nested structs prefix the names of their fields
+// with their own name. Variables that are not set leave the field nil, while variables set to an empty string set
+// it. Values are parsed according to the type of the field like the `default` tags of ApplyDefaults, except that
+// slices may also be given as comma separated values.
+func DecodeEnv(prefix string, prototype any, options ...option) (any, error) {
+	instance := reflect.ValueOf(newNullified(prototype, options...))
+	if instance.Kind() != reflect.Pointer || !isNullifiedStruct(instance.Type().Elem()) {
+		return nil, fmt.Errorf("nullify: prototype must be a struct, got %T", prototype)
+	}
+
+	if _, err := decodeEnv(instance.Elem(), prefix); err != nil {
+		return nil, err
+	}
+	return instance.Interface(), nil
+}
+
+// decodeEnv fills the fields of the nullified struct dst from the environment, returning true if any were set
+func decodeEnv(dst reflect.Value, prefix string) (bool, error) {
+	set := false
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		name, ok := envName(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		value := dst.Field(i)
+		if value.Kind() == reflect.Pointer && isNullifiedStruct(value.Type().Elem()) {
+			nested := reflect.New(value.Type().Elem())
+			ok, err := decodeEnv(nested.Elem(), name)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				value.Set(nested)
+				set = true
+			}
+			continue
+		}
+
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := parseEnv(value, env); err != nil {
+			return false, fmt.Errorf("nullify: %s: %w", name, err)
+		}
+		set = true
+	}
+	return set, nil
+}
+
+// parseEnv parses the value of an environment variable into v, splitting comma separated values for slices
+func parseEnv(v reflect.Value, env string) error {
+	elem := v.Type()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Slice || strings.HasPrefix(strings.TrimSpace(env), "[") {
+		return parseText(v, env)
+	}
+
+	values := strings.Split(env, ",")
+	if env == "" {
+		values = nil
+	}
+	slice := reflect.MakeSlice(elem, len(values), len(values))
+	for i, value := range values {
+		if err := parseText(slice.Index(i), strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	v.Set(slice)
+	return nil
+}
+
+// envName returns the name of the environment variable of field from the `env` tag or the upper snake case of the
+// Go field name, false if the field is ignored (`env:"-"`)
+func envName(field reflect.StructField) (string, bool) {
+	value := field.Tag.Get("env")
+	if value == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return strings.ToUpper(snakeCase(field.Name)), true
+	}
+	return name, true
+}
@@ -0,0 +1,125 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDecodeEnv(t *testing.T) {
+	type Database struct {
+		URL      string
+		MaxConns int
+	}
+	type Settings struct {
+		Name     string        `env:"APP_NAME"`
+		Debug    bool          `env:"DEBUG"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+		Tags     []string      `env:"TAGS"`
+		Ports    []int         `env:"PORTS"`
+		Database Database
+		Ignored  string `env:"-"`
+	}
+
+	tests := map[string]struct {
+		Env      map[string]string
+		Expected map[string]any
+	}{
+		"unset": {
+			Env:      map[string]string{},
+			Expected: map[string]any{},
+		},
+		"empty is set": {
+			Env:      map[string]string{"TEST_APP_NAME": "", "TEST_TAGS": ""},
+			Expected: map[string]any{"Name": "", "Tags": []any{}},
+		},
+		"values": {
+			Env: map[string]string{
+				"TEST_APP_NAME":           "app",
+				"TEST_DEBUG":              "true",
+				"TEST_TIMEOUT":            "1m",
+				"TEST_TAGS":               "a, b",
+				"TEST_PORTS":              "[80, 443]",
+				"TEST_DATABASE_MAX_CONNS": "10",
+				"TEST_IGNORED":            "x",
+			},
+			Expected: map[string]any{
+				"Name":     "app",
+				"Debug":    true,
+				"Timeout":  time.Minute,
+				"Tags":     []any{"a", "b"},
+				"Ports":    []any{80, 443},
+				"Database": map[string]any{"MaxConns": 10},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			for key, value := range testData.Env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			p, err := DecodeEnv("TEST", Settings{})
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testData.Expected, plainOf(p))
+		})
+	}
+}
+
+func TestDecodeEnv_ValueResult(t *testing.T) {
+	// Arrange
+	type Database struct {
+		URL      string
+		MaxConns int
+	}
+	type Settings struct {
+		Name     string        `env:"APP_NAME"`
+		Debug    bool          `env:"DEBUG"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+		Tags     []string      `env:"TAGS"`
+		Ports    []int         `env:"PORTS"`
+		Database Database
+		Ignored  string `env:"-"`
+	}
+
+	t.Setenv("TEST_APP_NAME", "app")
+
+	// Act
+	p, err := DecodeEnv("TEST", Settings{}, ValueResult{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"Name": "app"}, plainOf(p))
+}
+
+func TestDecodeEnv_Errors(t *testing.T) {
+	// Arrange
+	type Database struct {
+		URL      string
+		MaxConns int
+	}
+	type Settings struct {
+		Name     string        `env:"APP_NAME"`
+		Debug    bool          `env:"DEBUG"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+		Tags     []string      `env:"TAGS"`
+		Ports    []int         `env:"PORTS"`
+		Database Database
+		Ignored  string `env:"-"`
+	}
+
+	t.Setenv("DEBUG", "maybe")
+
+	// Act
+	_, err := DecodeEnv("", Settings{})
+	_, notStruct := DecodeEnv("", 1)
+
+	// Assert
+	assert.EqualError(t, err, `nullify: DEBUG: strconv.ParseBool: parsing "maybe": invalid syntax`)
+	assert.EqualError(t, notStruct, "nullify: prototype must be a struct, got int")
+}
@@ -0,0 +1,128 @@
+package nullify
+
+import (
+	"reflect"
+	"time"
+)
+
+// NilAsZero if true (default false) makes Equal treat a nil pointer as equal to a pointer to the zero value
+type NilAsZero struct {
+	Value bool
+}
+
+func (o NilAsZero) update(cfg config) config {
+	cfg.nilAsZero = o.Value
+	return cfg
+}
+
+// Equal reports whether the nullified values a and b are semantically equal. Unlike reflect.DeepEqual it
+// dereferences pointers before comparing, such that two nil pointers are equal and two non-nil pointers are equal
+// when the values they point to are equal. Leaves such as big.Int are compared as a whole, time.Time by the instant
+// it represents. Use NilAsZero to additionally treat nil as equal to the zero value.
+func Equal(a any, b any, options ...option) bool {
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b), newConfig(options...))
+}
+
+// equal compares a and b after dereferencing pointers and interfaces
+func equal(a reflect.Value, b reflect.Value, cfg config) bool {
+	a, aSet := indirect(a)
+	b, bSet := indirect(b)
+	switch {
+	case !aSet && !bSet:
+		return true
+	case !aSet || !bSet:
+		return cfg.nilAsZero && isZero(a) && isZero(b)
+	case a.Type() != b.Type():
+		return false
+	}
+
+	if a.Kind() == reflect.Struct && !isNullifiedStruct(a.Type()) {
+		// leaves such as time.Time and big.Int, whose unexported fields cannot be compared one by one
+		return equalLeaf(a, b)
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equal(a.Field(i), b.Field(i), cfg) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equal(a.Index(i), b.Index(i), cfg) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			bKey, ok := findKey(b, key, cfg)
+			if !ok || !equal(a.MapIndex(key), b.MapIndex(bKey), cfg) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equalLeaf(a, b)
+	}
+}
+
+// equalLeaf compares the values a and b of the same type as a whole, time.Time by the instant it represents
+func equalLeaf(a reflect.Value, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// findKey returns the key of m that is equal to key. Pointer keys (see NullifyMapKey) are compared by the value
+// they point to rather than by address.
+func findKey(m reflect.Value, key reflect.Value, cfg config) (reflect.Value, bool) {
+	if key.Kind() != reflect.Pointer && key.Kind() != reflect.Interface {
+		if m.MapIndex(key).IsValid() {
+			return key, true
+		}
+		return reflect.Value{}, false
+	}
+
+	for _, candidate := range m.MapKeys() {
+		if equal(key, candidate, cfg) {
+			return candidate, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// isZero returns true if v, after dereferencing, is unset or holds the zero value. Structs are zero when all their
+// fields are zero, such that a struct of nil pointers and a struct of pointers to zero values are both zero.
+func isZero(v reflect.Value) bool {
+	v, ok := indirect(v)
+	if !ok {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZero(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,105 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func decodeEqualPerson(t *testing.T, payload string) any {
+	type Person struct {
+		Name   string            `json:"name"`
+		Age    int               `json:"age"`
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(payload), p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]struct {
+		A       string
+		B       string
+		Options []option
+		Equal   bool
+	}{
+		"empty":             {A: `{}`, B: `{}`, Equal: true},
+		"same values":       {A: `{"name": "alice", "tags": ["a"], "labels": {"k": "v"}}`, B: `{"labels": {"k": "v"}, "tags": ["a"], "name": "alice"}`, Equal: true},
+		"different values":  {A: `{"name": "alice"}`, B: `{"name": "bob"}`, Equal: false},
+		"different keys":    {A: `{"labels": {"k": "v"}}`, B: `{"labels": {"x": "v"}}`, Equal: false},
+		"nil and zero":      {A: `{"age": 0}`, B: `{}`, Equal: false},
+		"nil as zero":       {A: `{"age": 0, "name": ""}`, B: `{}`, Options: []option{NilAsZero{Value: true}}, Equal: true},
+		"nil as zero slice": {A: `{"tags": []}`, B: `{}`, Options: []option{NilAsZero{Value: true}}, Equal: true},
+		"nil and non-zero":  {A: `{"age": 1}`, B: `{}`, Options: []option{NilAsZero{Value: true}}, Equal: false},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			a := decodeEqualPerson(t, testData.A)
+			b := decodeEqualPerson(t, testData.B)
+
+			// Act
+			output := Equal(a, b, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Equal, output)
+			assert.Equal(t, testData.Equal, Equal(b, a, testData.Options...))
+		})
+	}
+}
+
+func TestEqual_DifferentTypes(t *testing.T) {
+	type Person struct {
+		Name   string            `json:"name"`
+		Age    int               `json:"age"`
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	assert.False(t, Equal(Nullify(Person{}), Nullify(struct{ Name string }{})))
+	assert.True(t, Equal(nil, nil))
+}
+
+func TestEqual_Leaves(t *testing.T) {
+	// Arrange
+	type Account struct {
+		Opened  time.Time `json:"opened"`
+		Balance *big.Int  `json:"balance"`
+	}
+	decode := func(payload string) any {
+		p := Nullify(Account{}, NumericOptions...)
+		if err := json.Unmarshal([]byte(payload), p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	tests := map[string]struct {
+		A     string
+		B     string
+		Equal bool
+	}{
+		"same":              {A: `{"opened": "2024-01-02T03:04:05Z", "balance": 12}`, B: `{"opened": "2024-01-02T03:04:05Z", "balance": 12}`, Equal: true},
+		"same instant":      {A: `{"opened": "2024-01-02T03:04:05Z"}`, B: `{"opened": "2024-01-02T05:04:05+02:00"}`, Equal: true},
+		"different time":    {A: `{"opened": "2024-01-02T03:04:05Z"}`, B: `{"opened": "2024-01-03T03:04:05Z"}`, Equal: false},
+		"different balance": {A: `{"balance": 12}`, B: `{"balance": 13}`, Equal: false},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output := Equal(decode(testData.A), decode(testData.B))
+
+			// Assert
+			assert.Equal(t, testData.Equal, output)
+		})
+	}
+}
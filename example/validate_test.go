@@ -74,6 +74,54 @@ func TestNullify_JsonUnmarshal(t *testing.T) {
 	}
 }
 
+func TestNullify_Unmarshal(t *testing.T) {
+	tests := map[string]struct {
+		Payload      string
+		Required     string
+		Optional     string
+		ErrorMessage string
+	}{
+		"missing all": {
+			Payload:      `{}`,
+			ErrorMessage: "Key: 'Required' Error:Field validation for 'Required' failed on the 'required' tag",
+		},
+		"invalid format optional": {
+			Payload:      `{"required": "89ec270d-8256-4b0e-b25c-39564b10f29e", "optional": "notanemail"}`,
+			ErrorMessage: "Key: 'Optional' Error:Field validation for 'Optional' failed on the 'email' tag",
+		},
+		"valid": {
+			Payload:  `{"required": "89ec270d-8256-4b0e-b25c-39564b10f29e", "optional": "test@example.com"}`,
+			Required: "89ec270d-8256-4b0e-b25c-39564b10f29e",
+			Optional: "test@example.com",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			validate := validator.New()
+			var some Some
+			ptrSome, err := nullify.Unmarshal([]byte(testData.Payload), &some)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			err = validate.Struct(ptrSome)
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, testData.Required, some.Required)
+				assert.Equal(t, testData.Optional, some.Optional)
+			} else {
+				assert.ErrorContains(t, err, testData.ErrorMessage)
+			}
+		})
+	}
+}
+
 type Person struct {
 	Name string `json:"name" validate:"required"`
 }
@@ -88,3 +136,12 @@ func ExampleNullify() {
 	// Output:
 	// Key: 'Name' Error:Field validation for 'Name' failed on the 'required' tag
 }
+
+func ExampleValidate() {
+	var some Some
+	p, _ := nullify.Unmarshal([]byte(`{"required": "invalid"}`), &some)
+	err := nullify.Validate(validator.New(), p)
+	fmt.Println(err)
+	// Output:
+	// Key: 'required' Error:Field validation for 'required' failed on the 'uuid' tag
+}
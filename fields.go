@@ -0,0 +1,96 @@
+package nullify
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldInfo describes a field of a struct type and how it is nullified
+type FieldInfo struct {
+	Path     string       // dotted json path of the field, e.g. address.city
+	Name     string       // json name of the field, e.g. city
+	Type     reflect.Type // type of the field in the original struct, e.g. string
+	Optional bool         // true if the nullified field can be nil (not set) while the original field cannot
+	Validate string       // validate tag of the nullified field, including rules added by e.g. OmitNil
+}
+
+// Fields returns a FieldInfo for every exported field of the struct prototype as it is nullified with options,
+// e.g. to document which fields of a request are optional. Fields are listed depth-first in declaration order, with
+// nested structs (or pointers to structs) after the field holding them. Like encoding/json, the fields of embedded
+// structs without a json name are listed as fields of their parent. Structs held by slices, arrays and maps are not
+// descended into, nor are fields that are left out of the json encoding (`json:"-"`) or of the nullified struct
+// (e.g. by SkipUnserializable) listed. It returns nil if prototype is not a struct or a pointer to a struct.
+func Fields(prototype any, options ...option) []FieldInfo {
+	t := reflect.TypeOf(prototype)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return collectFields(nil, t, "", newConfig(options...))
+}
+
+// collectFields appends the FieldInfo of the fields of the struct type t to infos, prefixing json names with path
+func collectFields(infos []FieldInfo, t reflect.Type, path string, cfg config) []FieldInfo {
+	cfg.visiting = append(slices.Clip(cfg.visiting), t)
+	for i := 0; i < t.NumField(); i++ {
+		original := t.Field(i)
+		if !original.IsExported() && !original.Anonymous {
+			continue
+		}
+		field, ok := nullifyField(original, cfg)
+		if !ok {
+			continue
+		}
+		name, ok := fieldName(field, cfg)
+		if !ok {
+			continue
+		}
+
+		// nested structs that are kept as leaves (e.g. time.Time) or recursive are not descended into
+		nested := indirectType(original.Type)
+		descend := isNullifiedStruct(indirectType(field.Type)) && !slices.Contains(cfg.visiting, nested)
+
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); original.Anonymous && tagName == "" && descend {
+			infos = collectFields(infos, nested, path, fieldConfig(original, cfg))
+			continue
+		}
+		if !original.IsExported() {
+			continue
+		}
+
+		fieldPath := joinPath(path, name)
+		infos = append(infos, FieldInfo{
+			Path:     fieldPath,
+			Name:     name,
+			Type:     original.Type,
+			Optional: isNilable(field.Type) && !isNilable(original.Type),
+			Validate: field.Tag.Get("validate"),
+		})
+		if descend {
+			infos = collectFields(infos, nested, fieldPath, fieldConfig(original, cfg))
+		}
+	}
+	return infos
+}
+
+// indirectType returns t with all pointers dereferenced
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isNilable returns true if values of t can be nil
+func isNilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
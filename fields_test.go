@@ -0,0 +1,47 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type Audit struct {
+		CreatedAt time.Time `json:"created_at"`
+	}
+	type User struct {
+		Audit
+		Name     string        `json:"name" validate:"min=2"`
+		Nickname *string       `json:"nickname"`
+		Address  *Address      `json:"address"`
+		Tags     []string      `json:"tags"`
+		Secret   string        `json:"-"`
+		Done     chan struct{} `json:"done"`
+		internal string
+	}
+
+	// Act
+	fields := Fields(&User{}, append(JsonOptions, OmitNil{Value: true})...)
+
+	// Assert
+	assert.Equal(t, []FieldInfo{
+		{Path: "created_at", Name: "created_at", Type: reflect.TypeOf(time.Time{}), Optional: true, Validate: ""},
+		{Path: "name", Name: "name", Type: reflect.TypeOf(""), Optional: true, Validate: "omitnil,min=2"},
+		{Path: "nickname", Name: "nickname", Type: reflect.TypeOf((*string)(nil)), Optional: false, Validate: ""},
+		{Path: "address", Name: "address", Type: reflect.TypeOf((*Address)(nil)), Optional: false, Validate: ""},
+		{Path: "address.city", Name: "city", Type: reflect.TypeOf(""), Optional: true, Validate: "required"},
+		{Path: "tags", Name: "tags", Type: reflect.TypeOf([]string{}), Optional: false, Validate: ""},
+	}, fields)
+}
+
+func TestFields_NotAStruct(t *testing.T) {
+	assert.Nil(t, Fields(nil))
+	assert.Nil(t, Fields(42))
+	assert.Nil(t, Fields(map[string]string{}))
+}
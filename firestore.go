@@ -0,0 +1,62 @@
+package nullify
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FirestoreUpdate is a single field update of a Firestore document, with the same fields as firestore.Update such
+// that it converts directly: `firestore.Update{Path: u.Path, Value: u.Value}`
+type FirestoreUpdate struct {
+	Path  string
+	Value any
+}
+
+// FirestoreUpdates returns an update for every field of the nullified value that is set (non-nil), in field order
+// and with the values dereferenced, for partial document updates with DocumentRef.Update. Nested structs are
+// addressed with dotted paths (`address.city`) such that only the provided subfields are updated.
+//
+// Field names follow the Firestore client: the name from the `firestore` tag or the Go field name. Fields tagged
+// `firestore:"-"` are skipped and embedded structs without a name are flattened into their parent. WithTagPriority
+// takes precedence over the `firestore` tag for naming fields.
+func FirestoreUpdates(nullified any, options ...option) []FirestoreUpdate {
+	var updates []FirestoreUpdate
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && isNullifiedStruct(v.Type()) {
+		firestoreUpdates(&updates, v, "", newConfig(options...))
+	}
+	return updates
+}
+
+// firestoreUpdates appends an update for each set field of the struct value v, prefixing paths with path
+func firestoreUpdates(updates *[]FirestoreUpdate, v reflect.Value, path string, cfg config) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("firestore"), ",")
+		if priority, found := priorityName(field, cfg); found {
+			name = priority
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		if name == "" && field.Anonymous && isNullifiedStruct(value.Type()) {
+			// the Firestore client flattens embedded structs without a name into their parent
+			firestoreUpdates(updates, value, path, cfg)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if isNullifiedStruct(value.Type()) {
+			firestoreUpdates(updates, value, path+name+".", cfg)
+			continue
+		}
+
+		*updates = append(*updates, FirestoreUpdate{Path: path + name, Value: value.Interface()})
+	}
+}
@@ -0,0 +1,61 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFirestoreUpdates(t *testing.T) {
+	type Base struct {
+		ID string `firestore:"id" json:"id"`
+	}
+	type Address struct {
+		Street string `firestore:"street" json:"street"`
+		City   string `firestore:"city" json:"city"`
+	}
+	type Person struct {
+		Base
+		Name    string  `firestore:"name" json:"name"`
+		Age     int     `json:"age"`
+		Address Address `firestore:"address" json:"address"`
+		Secret  string  `firestore:"-" json:"secret"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected []FirestoreUpdate
+	}{
+		"empty": {
+			Payload: `{}`,
+		},
+		"set fields": {
+			Payload: `{"name": "alice", "age": 0, "address": {"city": "Springfield"}, "secret": "x"}`,
+			Expected: []FirestoreUpdate{
+				{Path: "name", Value: "alice"},
+				{Path: "Age", Value: 0},
+				{Path: "address.city", Value: "Springfield"},
+			},
+		},
+		"embedded": {
+			Payload:  `{"id": "x", "name": "n"}`,
+			Expected: []FirestoreUpdate{{Path: "id", Value: "x"}, {Path: "name", Value: "n"}},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			updates := FirestoreUpdates(p)
+
+			// Assert
+			assert.Equal(t, testData.Expected, updates)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package nullify
+
+import (
+	"reflect"
+)
+
+// Flatten returns the fields of the nullified value that are set (non-nil) keyed by their dotted json path, e.g.
+// "address.street" -> "Main St", with the values dereferenced. Nested structs are flattened into the paths of their
+// fields, embedded structs without a json name are flattened into their parent like encoding/json does, while
+// slices and maps are single entries holding their dereferenced elements. Names follow WithTagPriority.
+func Flatten(nullified any, options ...option) map[string]any {
+	flat := map[string]any{}
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && isNullifiedStruct(v.Type()) {
+		flatten(v, "", newConfig(options...), func(path string, value any) bool {
+			flat[path] = value
+			return true
+		})
+	}
+	return flat
+}
+
+// flatten calls yield for the set fields of the nullified struct v in field order, prefixing names with the dotted
+// path. Names of embedded fields are resolved like encoding/json does (see encodedFields). It returns false if yield
+// did, stopping the traversal.
+func flatten(v reflect.Value, path string, cfg config, yield func(path string, value any) bool) bool {
+	for _, encoded := range encodedFields(v.Type(), cfg) {
+		field, ok := encoded.value(v)
+		if !ok {
+			continue
+		}
+		value, ok := present(field, cfg)
+		if !ok {
+			continue
+		}
+
+		if isNullifiedStruct(value.Type()) {
+			if !flatten(value, path+encoded.name+".", cfg, yield) {
+				return false
+			}
+			continue
+		}
+
+		plainValue, _ := plain(value)
+		if !yield(path+encoded.name, plainValue) {
+			return false
+		}
+	}
+	return true
+}
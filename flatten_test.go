@@ -0,0 +1,104 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Secret  string   `json:"-"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected map[string]any
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: map[string]any{},
+		},
+		"set fields": {
+			Payload: `{"id": "1", "name": "", "tags": ["a"], "address": {"street": "Main St"}}`,
+			Expected: map[string]any{
+				"id":             "1",
+				"name":           "",
+				"tags":           []any{"a"},
+				"address.street": "Main St",
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			flat := Flatten(p)
+
+			// Assert
+			assert.Equal(t, testData.Expected, flat)
+		})
+	}
+}
+
+func TestFlatten_NotNullified(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Secret  string   `json:"-"`
+	}
+
+	// Act & Assert
+	assert.Equal(t, map[string]any{}, Flatten(nil))
+	assert.Equal(t, map[string]any{}, Flatten(&Person{}))
+}
+
+func TestFlatten_EmbeddedConflicts(t *testing.T) {
+	// Arrange
+	type Untagged struct {
+		ID string
+	}
+	type Shadow struct {
+		ID string
+		Untagged
+	}
+
+	original := &Shadow{ID: "outer", Untagged: Untagged{ID: "inner"}}
+	p := Nullify(original)
+	if err := CopyMatching(original, p, MatchFields{Value: MatchGoName}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	flat := Flatten(p)
+
+	// Assert
+	assert.Equal(t, map[string]any{"ID": "outer"}, flat)
+}
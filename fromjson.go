@@ -0,0 +1,148 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FromJSON returns a new instance of a nullified struct type inferred from the sample JSON object, e.g. to validate
+// payloads whose schema is only known at runtime. Objects become structs with a json tag per key (sorted by key),
+// arrays become slices and strings, numbers and booleans become string, float64 and bool leaves like encoding/json
+// decodes them into an any, before the type is nullified with options like Nullify does. Elements of arrays are
+// merged: objects into a struct with the keys of all of them, while arrays mixing kinds (or holding only nulls)
+// become []any, like null values. Keys that cannot be expressed as a json tag name (containing ", \ or a comma)
+// are skipped.
+func FromJSON(sample []byte, options ...option) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(sample))
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("nullify: invalid sample: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("nullify: invalid data after top-level value")
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("nullify: sample must be a json object, got %s", sampleKind(value))
+	}
+	t := inferStruct([]map[string]any{object})
+	return Nullify(reflect.Zero(t).Interface(), options...), nil
+}
+
+// inferType returns the type of the decoded json values, which are the sample values at the same location
+func inferType(values []any) reflect.Type {
+	var objects []map[string]any
+	var arrays []any
+	kinds := map[string]bool{}
+	for _, value := range values {
+		switch value := value.(type) {
+		case nil:
+			continue
+		case map[string]any:
+			objects = append(objects, value)
+		case []any:
+			arrays = append(arrays, value...)
+		}
+		kinds[sampleKind(value)] = true
+	}
+	if len(kinds) != 1 {
+		return anyType
+	}
+
+	switch {
+	case kinds["object"]:
+		return inferStruct(objects)
+	case kinds["array"]:
+		return reflect.SliceOf(inferType(arrays))
+	case kinds["string"]:
+		return reflect.TypeOf("")
+	case kinds["number"]:
+		return reflect.TypeOf(float64(0))
+	default:
+		return reflect.TypeOf(false)
+	}
+}
+
+// inferStruct returns a struct type with a field per key of the objects
+func inferStruct(objects []map[string]any) reflect.Type {
+	values := map[string][]any{}
+	for _, object := range objects {
+		for key, value := range object {
+			values[key] = append(values[key], value)
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "" && !strings.ContainsAny(key, "\"\\,") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	used := make(map[string]bool, len(keys))
+	fields := make([]reflect.StructField, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, reflect.StructField{
+			Name: exportedName(key, used),
+			Type: inferType(values[key]),
+			Tag:  reflect.StructTag(`json:"` + key + `"`),
+		})
+	}
+	return reflect.StructOf(fields)
+}
+
+// sampleKind returns the json kind of the decoded json value, e.g. object
+func sampleKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	default:
+		return "boolean"
+	}
+}
+
+// exportedName converts a json key to a unique exported Go identifier, e.g. first_name becomes FirstName
+func exportedName(key string, used map[string]bool) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = "X" + name
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
@@ -0,0 +1,91 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	// Arrange
+	sample := `{
+		"name": "alice",
+		"age": 42,
+		"active": true,
+		"first_name": "Alice",
+		"note": null,
+		"address": {"street": "Main St"},
+		"tags": ["a", "b"],
+		"friends": [{"name": "bob"}, {"age": 7}],
+		"mixed": [1, "a"],
+		"matrix": [[1, 2], [3]],
+		"bad\"key": 1
+	}`
+
+	// Act
+	p, err := FromJSON([]byte(sample))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(&struct {
+		Active  *bool `json:"active"`
+		Address *struct {
+			Street *string `json:"street"`
+		} `json:"address"`
+		Age       *float64 `json:"age"`
+		FirstName *string  `json:"first_name"`
+		Friends   *[]*struct {
+			Age  *float64 `json:"age"`
+			Name *string  `json:"name"`
+		} `json:"friends"`
+		Matrix *[]*[]*float64 `json:"matrix"`
+		Mixed  *[]any         `json:"mixed"`
+		Name   *string        `json:"name"`
+		Note   *any           `json:"note"`
+		Tags   *[]*string     `json:"tags"`
+	}{}), reflect.TypeOf(p))
+}
+
+func TestFromJSON_Validate(t *testing.T) {
+	// Arrange
+	p, err := FromJSON([]byte(`{"name": "alice", "address": {"city": "Springfield"}}`), JsonOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"address": {"city": "Shelbyville"}}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	report, err := ValidateAll(validator.New(), p)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+	assert.Equal(t, map[string]any{"address.city": "Shelbyville"}, Flatten(p))
+}
+
+func TestFromJSON_Error(t *testing.T) {
+	tests := map[string]struct {
+		Sample       string
+		ErrorMessage string
+	}{
+		"malformed":        {Sample: `{`, ErrorMessage: "nullify: invalid sample: unexpected EOF"},
+		"array":            {Sample: `[{}]`, ErrorMessage: "nullify: sample must be a json object, got array"},
+		"trailing":         {Sample: `{} {}`, ErrorMessage: "nullify: invalid data after top-level value"},
+		"trailing garbage": {Sample: `{"a": 1} xyz`, ErrorMessage: "nullify: invalid data after top-level value"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p, err := FromJSON([]byte(testData.Sample))
+
+			// Assert
+			assert.Nil(t, p)
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
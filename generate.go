@@ -0,0 +1,235 @@
+package nullify
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const generateAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Generate returns a nullified instance of prototype with randomized presence and values drawn from r, e.g. for
+// property-based and fuzz testing of PATCH handlers. Each optional field is set with a probability of one half,
+// fields with a required validate rule are always set.
+//
+// Values respect the simple rules of the `validate` tag where feasible: uuid, email, url, oneof, len, min, max, gt,
+// gte, lt and lte. Other rules are ignored, interfaces are left nil. Nested structs, slices (of up to three elements
+// unless bounded), maps and arrays are generated recursively.
+func Generate(prototype any, r *rand.Rand, options ...option) any {
+	instance := newNullified(prototype, options...)
+	if instance == nil {
+		return nil
+	}
+	generate(reflect.ValueOf(instance).Elem(), nil, r)
+	return instance
+}
+
+// generate fills v with random values respecting the validate rules
+func generate(v reflect.Value, rules map[string]string, r *rand.Rand) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		generate(elem.Elem(), rules, r)
+		v.Set(elem)
+		return
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(time.Unix(946684800+r.Int63n(30*365*24*3600), 0).UTC()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			fieldRules := validateRules(field.Tag.Get("validate"))
+			_, required := fieldRules["required"]
+			if isNil(v.Field(i)) && !required && r.Intn(2) == 0 {
+				continue
+			}
+			generate(v.Field(i), fieldRules, r)
+		}
+		return
+	}
+
+	lower, upper, bounded := generateBounds(rules, v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(generateString(rules, r))
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !bounded {
+			lower, upper = 0, 100
+		}
+		v.SetInt(int64(lower) + r.Int63n(int64(upper-lower)+1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !bounded || lower < 0 {
+			lower, upper = 0, 100
+		}
+		v.SetUint(uint64(lower) + uint64(r.Int63n(int64(upper-lower)+1)))
+	case reflect.Float32, reflect.Float64:
+		if !bounded {
+			lower, upper = 0, 100
+		}
+		v.SetFloat(generateFloat(rules, lower, upper, r))
+	case reflect.Slice:
+		n := generateLength(rules, r)
+		slice := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			generate(slice.Index(i), nil, r)
+		}
+		v.Set(slice)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			generate(v.Index(i), nil, r)
+		}
+	case reflect.Map:
+		n := generateLength(rules, r)
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			generate(key, nil, r)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			generate(elem, nil, r)
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+	}
+}
+
+// validateRules returns the rules of a validate tag value that apply to the field itself (i.e. before any dive) by
+// name, e.g. min=1 becomes "min" -> "1"
+func validateRules(value string) map[string]string {
+	rules := map[string]string{}
+	for _, rule := range strings.Split(value, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" || name == "keys" {
+			break
+		}
+		if name != "" {
+			rules[name] = param
+		}
+	}
+	return rules
+}
+
+// generateBounds returns the numeric bounds of the len, min, max, gt, gte, lt and lte rules, which bound the value
+// of numbers and the length of strings and containers. For integers the bounds are inclusive (gt and lt are moved
+// by one), for other numbers the bounds of gt and lt are exclusive (see generateFloat). bounded is false if no rule
+// applies or the bounds leave no values.
+func generateBounds(rules map[string]string, integer bool) (lower float64, upper float64, bounded bool) {
+	step := 0.0
+	if integer {
+		step = 1
+	}
+
+	lower, upper = 0, 0
+	hasLower, hasUpper := false, false
+	for name, param := range rules {
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			continue
+		}
+		switch name {
+		case "len":
+			return f, f, true
+		case "min", "gte":
+			lower, hasLower = f, true
+		case "gt":
+			lower, hasLower = f+step, true
+		case "max", "lte":
+			upper, hasUpper = f, true
+		case "lt":
+			upper, hasUpper = f-step, true
+		}
+	}
+
+	_, gt := rules["gt"]
+	_, lt := rules["lt"]
+	open := !integer && (gt || lt)
+	switch {
+	case hasLower && hasUpper:
+		return lower, upper, upper > lower || (upper == lower && !open)
+	case hasLower:
+		return lower, lower + 100, true
+	case hasUpper && open:
+		return min(0, upper-1), upper, true
+	case hasUpper:
+		return min(0, upper), upper, true
+	}
+	return 0, 0, false
+}
+
+// generateFloat returns a random number between lower and upper, excluding the bounds of the gt and lt rules
+func generateFloat(rules map[string]string, lower float64, upper float64, r *rand.Rand) float64 {
+	_, gt := rules["gt"]
+	_, lt := rules["lt"]
+	for {
+		f := lower + r.Float64()*(upper-lower)
+		if (!gt || f > lower) && (!lt || f < upper) {
+			return f
+		}
+	}
+}
+
+// generateLength returns a random length for a string or container respecting the bounds of rules
+func generateLength(rules map[string]string, r *rand.Rand) int {
+	lower, upper, bounded := generateBounds(rules, true)
+	if !bounded || lower < 0 {
+		lower, upper = 0, 3
+	}
+	return int(lower) + r.Intn(int(upper-lower)+1)
+}
+
+// generateString returns a random string respecting the format rules (uuid, email, url and oneof) or the length
+// bounds of rules
+func generateString(rules map[string]string, r *rand.Rand) string {
+	if options, ok := rules["oneof"]; ok {
+		if values := strings.Fields(options); len(values) > 0 {
+			return values[r.Intn(len(values))]
+		}
+	}
+
+	for name := range rules {
+		switch name {
+		case "uuid", "uuid4":
+			b := make([]byte, 16)
+			r.Read(b)
+			b[6] = b[6]&0x0f | 0x40
+			b[8] = b[8]&0x3f | 0x80
+			const hex = "0123456789abcdef"
+			var s strings.Builder
+			for i, c := range b {
+				if i == 4 || i == 6 || i == 8 || i == 10 {
+					s.WriteByte('-')
+				}
+				s.WriteByte(hex[c>>4])
+				s.WriteByte(hex[c&0x0f])
+			}
+			return s.String()
+		case "email":
+			return randomString(8, r) + "@example.com"
+		case "url", "http_url":
+			return "https://example.com/" + randomString(8, r)
+		}
+	}
+
+	lower, upper, bounded := generateBounds(rules, true)
+	if !bounded || lower < 0 {
+		lower, upper = 1, 10
+	}
+	return randomString(int(lower)+r.Intn(int(upper-lower)+1), r)
+}
+
+// randomString returns a random alphanumeric string of length n
+func randomString(n int, r *rand.Rand) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = generateAlphabet[r.Intn(len(generateAlphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,127 @@
+package nullify
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required,min=3,max=20"`
+	}
+	type Person struct {
+		ID       string            `json:"id" validate:"required,uuid"`
+		Email    string            `json:"email" validate:"omitnil,email"`
+		Website  string            `json:"website" validate:"omitnil,url"`
+		Kind     string            `json:"kind" validate:"omitnil,oneof=admin user"`
+		Age      int               `json:"age" validate:"omitnil,gte=18,lte=99"`
+		Score    float64           `json:"score" validate:"omitnil,gt=0,lt=10"`
+		Tags     []string          `json:"tags" validate:"omitnil,min=1,max=2,dive,required"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Any      any               `json:"any"`
+	}
+
+	v := validator.New()
+	set := map[string]int{}
+
+	for seed := int64(0); seed < 100; seed++ {
+		// Act
+		instance := Generate(Person{}, rand.New(rand.NewSource(seed)))
+
+		// Assert
+		assert.NoError(t, v.Struct(instance))
+		for path := range Flatten(instance) {
+			set[path]++
+		}
+	}
+	assert.Equal(t, 100, set["id"])
+	for _, path := range []string{"email", "website", "kind", "age", "score", "tags", "birthday", "address.street"} {
+		assert.Greater(t, set[path], 0, path)
+		assert.Less(t, set[path], 100, path)
+	}
+}
+
+func TestGenerate_FloatBounds(t *testing.T) {
+	// Arrange
+	type Measurement struct {
+		Ratio    float64 `json:"ratio" validate:"required,gt=0,lt=1"`
+		Negative float32 `json:"negative" validate:"required,lt=0"`
+		Exact    float64 `json:"exact" validate:"required,gte=0.5,lte=0.5"`
+		Count    int     `json:"count" validate:"required,gt=0,lt=2"`
+	}
+	v := validator.New()
+
+	for seed := int64(0); seed < 100; seed++ {
+		// Act
+		instance := Generate(Measurement{}, rand.New(rand.NewSource(seed)))
+
+		// Assert
+		assert.NoError(t, v.Struct(instance))
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required,min=3,max=20"`
+	}
+	type Person struct {
+		ID       string            `json:"id" validate:"required,uuid"`
+		Email    string            `json:"email" validate:"omitnil,email"`
+		Website  string            `json:"website" validate:"omitnil,url"`
+		Kind     string            `json:"kind" validate:"omitnil,oneof=admin user"`
+		Age      int               `json:"age" validate:"omitnil,gte=18,lte=99"`
+		Score    float64           `json:"score" validate:"omitnil,gt=0,lt=10"`
+		Tags     []string          `json:"tags" validate:"omitnil,min=1,max=2,dive,required"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Any      any               `json:"any"`
+	}
+
+	// Act
+	a := Generate(Person{}, rand.New(rand.NewSource(1)))
+	b := Generate(Person{}, rand.New(rand.NewSource(1)))
+
+	// Assert
+	assert.True(t, Equal(a, b))
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{})), reflect.TypeOf(a))
+}
+
+func TestGenerate_Nil(t *testing.T) {
+	assert.Nil(t, Generate(nil, rand.New(rand.NewSource(1))))
+}
+
+func TestGenerate_ValueResult(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required,min=3,max=20"`
+	}
+	type Person struct {
+		ID       string            `json:"id" validate:"required,uuid"`
+		Email    string            `json:"email" validate:"omitnil,email"`
+		Website  string            `json:"website" validate:"omitnil,url"`
+		Kind     string            `json:"kind" validate:"omitnil,oneof=admin user"`
+		Age      int               `json:"age" validate:"omitnil,gte=18,lte=99"`
+		Score    float64           `json:"score" validate:"omitnil,gt=0,lt=10"`
+		Tags     []string          `json:"tags" validate:"omitnil,min=1,max=2,dive,required"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Any      any               `json:"any"`
+	}
+
+	// Act
+	instance := Generate(Person{}, rand.New(rand.NewSource(1)), ValueResult{Value: true})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{})), reflect.TypeOf(instance))
+	assert.NoError(t, validator.New().Struct(instance))
+}
@@ -2,10 +2,22 @@ module github.com/Emptyless/nullify
 
 go 1.21.7
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/go-playground/validator/v10 v10.19.0
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.34.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gabriel-vasile/mimetype v1.4.3 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.19.0 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,99 @@
+package nullify
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// UpdateMap returns the fields of the nullified value that are set (non-nil), keyed by their column name and
+// with their values dereferenced, ready to be passed to GORM: `db.Model(&m).Updates(nullify.UpdateMap(patch))`.
+//
+// The column name is taken from the `gorm:"column:..."` tag, the json tag or the snake_case field name, in that
+// order, unless WithTagPriority names the field. Fields tagged `gorm:"-"` are skipped. Embedded structs and fields
+// tagged `gorm:"embedded"` are flattened (respecting embeddedPrefix), other nested structs are associations rather
+// than columns and are skipped.
+func UpdateMap(nullified any, options ...option) map[string]any {
+	updates := map[string]any{}
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && v.Kind() == reflect.Struct {
+		updateMap(updates, v, "", newConfig(options...))
+	}
+	return updates
+}
+
+// updateMap adds the set fields of the struct value v to updates, prefixing column names with prefix
+func updateMap(updates map[string]any, v reflect.Value, prefix string, cfg config) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		settings := gormSettings(field.Tag.Get("gorm"))
+		if _, skip := settings["-"]; skip || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		_, embedded := settings["embedded"]
+		if isNullifiedStruct(value.Type()) {
+			if field.Anonymous || embedded {
+				updateMap(updates, value, prefix+settings["embeddedprefix"], cfg)
+			}
+			continue
+		}
+
+		column, found := priorityName(field, cfg)
+		if column == "-" {
+			continue
+		}
+		if !found {
+			column = columnName(field, settings)
+		}
+		updates[prefix+column] = value.Interface()
+	}
+}
+
+// gormSettings parses a gorm tag (e.g. `column:name;embedded`) into its lower-cased keys and values
+func gormSettings(tag string) map[string]string {
+	settings := map[string]string{}
+	for _, setting := range strings.Split(tag, ";") {
+		key, value, _ := strings.Cut(setting, ":")
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			if strings.HasPrefix(key, "-") {
+				key = "-"
+			}
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+// columnName returns the column of field from the gorm settings, the json tag or the snake_case field name
+func columnName(field reflect.StructField, settings map[string]string) string {
+	if column := settings["column"]; column != "" {
+		return column
+	}
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return snakeCase(field.Name)
+}
+
+// snakeCase converts a Go identifier to snake_case keeping initialisms together, e.g. UserID becomes user_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+			if startsWord {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestUpdateMap(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		UpdatedBy string
+	}
+	type Address struct {
+		Street string
+	}
+	type Company struct {
+		Name string
+	}
+	type User struct {
+		Audit
+		ID        string
+		FirstName string    `gorm:"column:given_name"`
+		LastName  string    `json:"surname"`
+		Age       int       `json:"age"`
+		Secret    string    `gorm:"-"`
+		Birthday  time.Time `json:"birthday"`
+		Address   Address   `gorm:"embedded;embeddedPrefix:address_"`
+		Company   Company
+	}
+
+	patch := Nullify(User{})
+	err := json.Unmarshal([]byte(`{
+		"UpdatedBy": "admin",
+		"FirstName": "alice",
+		"surname": "smith",
+		"Secret": "s3cr3t",
+		"birthday": "2000-01-02T00:00:00Z",
+		"Address": {"Street": "Main St"},
+		"Company": {"Name": "ACME"}
+	}`), patch)
+	assert.Nil(t, err)
+
+	// Act
+	updates := UpdateMap(patch)
+
+	// Assert
+	assert.Equal(t, map[string]any{
+		"updated_by":     "admin",
+		"given_name":     "alice",
+		"surname":        "smith",
+		"birthday":       time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+		"address_street": "Main St",
+	}, updates)
+}
+
+func TestUpdateMap_BareContainers(t *testing.T) {
+	// Arrange
+	type User struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	patch := Nullify(User{}, BareContainers{Value: true})
+	assert.Nil(t, json.Unmarshal([]byte(`{"name": "alice"}`), patch))
+
+	// Act
+	updates := UpdateMap(patch, BareContainers{Value: true})
+
+	// Assert
+	assert.Equal(t, map[string]any{"name": "alice"}, updates)
+}
+
+func TestUpdateMap_Nil(t *testing.T) {
+	assert.Equal(t, map[string]any{}, UpdateMap(nil))
+	assert.Equal(t, map[string]any{}, UpdateMap("string"))
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"FirstName": "first_name",
+		"HTTPCode":  "http_code",
+		"Address2":  "address2",
+		"name":      "name",
+	}
+	for input, output := range tests {
+		input, output := input, output
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, output, snakeCase(input))
+		})
+	}
+}
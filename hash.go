@@ -0,0 +1,99 @@
+package nullify
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// hashEnd terminates structs and elements, such that nested or nil values cannot collide with their neighbours
+const hashEnd = math.MaxUint64
+
+// Hash returns a stable 64-bit FNV-1a hash of the set fields of the nullified value and their dereferenced values,
+// e.g. to dedupe repeated patch requests or to derive idempotency keys. Nil fields are excluded, such that values
+// that are Equal have the same hash regardless of the addresses they point to. Maps are hashed independent of their
+// iteration order and times by their instant. The hash is stable across processes, but depends on the Go field
+// names: renaming a field changes the hash of the values setting it.
+func Hash(nullified any) uint64 {
+	h := fnv.New64a()
+	hashValue(h, reflect.ValueOf(nullified))
+	return h.Sum64()
+}
+
+// hashValue writes the dereferenced value v to h, nothing if v is not set
+func hashValue(h hash.Hash64, v reflect.Value) {
+	v, ok := indirect(v)
+	if !ok {
+		return
+	}
+
+	_, _ = h.Write([]byte{byte(v.Kind())})
+	if v.Type() == timeType && v.CanInterface() {
+		hashUint(h, uint64(v.Interface().(time.Time).UnixNano()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if _, ok := indirect(v.Field(i)); ok {
+				hashString(h, v.Type().Field(i).Name)
+				hashValue(h, v.Field(i))
+			}
+		}
+		hashUint(h, hashEnd)
+	case reflect.Slice, reflect.Array:
+		hashUint(h, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+			hashUint(h, hashEnd)
+		}
+	case reflect.Map:
+		entries := make([]uint64, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := fnv.New64a()
+			hashValue(entry, iter.Key())
+			hashUint(entry, hashEnd)
+			hashValue(entry, iter.Value())
+			entries = append(entries, entry.Sum64())
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+		hashUint(h, uint64(len(entries)))
+		for _, entry := range entries {
+			hashUint(h, entry)
+		}
+	case reflect.String:
+		hashString(h, v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			hashUint(h, 1)
+		} else {
+			hashUint(h, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hashUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		hashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		hashUint(h, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		hashUint(h, math.Float64bits(real(v.Complex())))
+		hashUint(h, math.Float64bits(imag(v.Complex())))
+	}
+}
+
+// hashUint writes u to h
+func hashUint(h hash.Hash64, u uint64) {
+	_, _ = h.Write(binary.LittleEndian.AppendUint64(nil, u))
+}
+
+// hashString writes the length prefixed s to h
+func hashString(h hash.Hash64, s string) {
+	hashUint(h, uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
@@ -0,0 +1,109 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHash(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Person struct {
+		Name     string            `json:"name"`
+		Age      int               `json:"age"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+	}
+
+	tests := map[string]struct {
+		A     string
+		B     string
+		Equal bool
+	}{
+		"same payload": {
+			A:     `{"name": "alice", "tags": ["a", "b"], "address": {"street": "Main St"}}`,
+			B:     `{"address": {"street": "Main St"}, "tags": ["a", "b"], "name": "alice"}`,
+			Equal: true,
+		},
+		"map order": {
+			A:     `{"labels": {"a": "1", "b": "2", "c": "3"}}`,
+			B:     `{"labels": {"c": "3", "b": "2", "a": "1"}}`,
+			Equal: true,
+		},
+		"time zone": {
+			A:     `{"birthday": "2000-01-02T10:00:00Z"}`,
+			B:     `{"birthday": "2000-01-02T12:00:00+02:00"}`,
+			Equal: true,
+		},
+		"different value": {
+			A: `{"name": "alice"}`,
+			B: `{"name": "bob"}`,
+		},
+		"unset versus zero": {
+			A: `{}`,
+			B: `{"age": 0}`,
+		},
+		"empty nested struct": {
+			A: `{}`,
+			B: `{"address": {}}`,
+		},
+		"nil element": {
+			A: `{"tags": ["a", null]}`,
+			B: `{"tags": [null, "a"]}`,
+		},
+		"moved value": {
+			A: `{"labels": {"a": "b"}}`,
+			B: `{"labels": {"b": "a"}}`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			a, b := Nullify(Person{}), Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.A), a); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(testData.B), b); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			hashA, hashB := Hash(a), Hash(b)
+
+			// Assert
+			assert.Equal(t, testData.Equal, hashA == hashB)
+		})
+	}
+}
+
+func TestHash_Stable(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Person struct {
+		Name     string            `json:"name"`
+		Age      int               `json:"age"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"name": "alice", "age": 42}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	hash := Hash(p)
+
+	// Assert
+	assert.Equal(t, uint64(0xafd9a5a6dce5a1d6), hash)
+}
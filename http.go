@@ -0,0 +1,118 @@
+package nullify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// DecodeAndValidate reads the JSON body of r into the nullified type of dst, runs the transformers registered with
+// Transform over it, validates it with v and, if it is valid, copies the fields that were present into dst.
+// Validation failures are returned as ValidationErrors referencing the json names of the fields, dst is left
+// untouched when an error is returned.
+//
+// dst must be a non-nil pointer.
+func DecodeAndValidate(r *http.Request, dst any, v StructValidator, options ...option) error {
+	_, err := decodeAndValidate(r, dst, v, options...)
+	return err
+}
+
+// Middleware decodes the JSON body of each request into the nullified type of T and validates it with v (see
+// DecodeAndValidate). Valid requests are passed to next with the populated T and the nullified value stored in
+// the request context, use FromContext and PresenceFromContext to retrieve them. Invalid requests are answered
+// with a 400 Bad Request and a JSON body describing the error without calling next.
+func Middleware[T any](v StructValidator, options ...option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var value T
+			presence, err := decodeAndValidate(r, &value, v, options...)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), requestKey{}, requestValue{value: value, presence: presence})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the value stored by Middleware in ctx, false if ctx holds no value of type T
+func FromContext[T any](ctx context.Context) (T, bool) {
+	stored, _ := ctx.Value(requestKey{}).(requestValue)
+	value, ok := stored.value.(T)
+	return value, ok
+}
+
+// PresenceFromContext returns the nullified value stored by Middleware in ctx, nil if ctx holds none
+func PresenceFromContext(ctx context.Context) any {
+	stored, _ := ctx.Value(requestKey{}).(requestValue)
+	return stored.presence
+}
+
+// requestKey is the context key of the requestValue stored by Middleware
+type requestKey struct{}
+
+// requestValue holds the decoded request body and its nullified value
+type requestValue struct {
+	value    any
+	presence any
+}
+
+// errorResponse is the JSON body written by Middleware for invalid requests
+type errorResponse struct {
+	Error  string           `json:"error"`
+	Fields ValidationErrors `json:"fields,omitempty"`
+}
+
+// writeError writes err as a 400 Bad Request errorResponse
+func writeError(w http.ResponseWriter, err error) {
+	response := errorResponse{Error: err.Error()}
+	var fieldErrors ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		response.Error = "validation failed"
+		response.Fields = fieldErrors
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// decodeAndValidate implements DecodeAndValidate and returns the nullified value
+func decodeAndValidate(r *http.Request, dst any, v StructValidator, options ...option) (any, error) {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Pointer || dstVal.IsNil() {
+		return nil, fmt.Errorf("nullify: dst must be a non-nil pointer, got %T", dst)
+	}
+	if r.Body == nil {
+		return nil, fmt.Errorf("nullify: request has no body")
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	presence := newNullified(dst, options...)
+	if err := json.Unmarshal(data, presence); err != nil {
+		return nil, err
+	}
+
+	if err := applyTransforms(r.Context(), presence, newConfig(options...)); err != nil {
+		return nil, err
+	}
+
+	if err := v.StructCtx(r.Context(), presence); err != nil {
+		return nil, validationErrors(presence, err)
+	}
+
+	if err := CopyMatching(presence, dst, options...); err != nil {
+		return nil, err
+	}
+	return presence, nil
+}
@@ -0,0 +1,191 @@
+package nullify
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAndValidate(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	tests := map[string]struct {
+		Body         string
+		Person       Person
+		ErrorMessage string
+	}{
+		"valid": {
+			Body:   `{"name": "alice", "address": {"street": "Main St"}}`,
+			Person: Person{Name: "alice", Address: Address{Street: "Main St"}},
+		},
+		"missing": {
+			Body:         `{"address": {}}`,
+			ErrorMessage: "Key: 'name' Error:Field validation for 'name' failed on the 'required' tag\nKey: 'address.street' Error:Field validation for 'address.street' failed on the 'required' tag",
+		},
+		"invalid": {
+			Body:         `{"name": "alice", "email_address": "invalid", "friends": [{}]}`,
+			ErrorMessage: "Key: 'email_address' Error:Field validation for 'email_address' failed on the 'email' tag\nKey: 'friends[0].street' Error:Field validation for 'friends[0].street' failed on the 'required' tag",
+		},
+		"malformed": {
+			Body:         `{`,
+			ErrorMessage: "unexpected end of JSON input",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			r := httptest.NewRequest("POST", "/", strings.NewReader(testData.Body))
+			var person Person
+
+			// Act
+			err := DecodeAndValidate(r, &person, validator.New(), JsonOptions...)
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, testData.Person, person)
+			} else {
+				assert.ErrorContains(t, err, testData.ErrorMessage)
+				assert.Equal(t, Person{}, person)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidate_ValueResult(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "alice", "address": {"street": "Main St"}}`))
+	var person Person
+
+	// Act
+	err := DecodeAndValidate(r, &person, validator.New(), append(JsonOptions, ValueResult{Value: true})...)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Person{Name: "alice", Address: Address{Street: "Main St"}}, person)
+}
+
+func TestDecodeAndValidate_ValidationErrors(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	var person Person
+
+	// Act
+	err := DecodeAndValidate(r, &person, validator.New())
+
+	// Assert
+	assert.Equal(t, ValidationErrors{
+		{Path: "name", Tag: "required"},
+	}, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	tests := map[string]struct {
+		Body   string
+		Status int
+		Output string
+	}{
+		"valid": {
+			Body:   `{"name": "alice", "address": {"street": "Main St"}}`,
+			Status: http.StatusOK,
+			Output: "alice true false",
+		},
+		"invalid": {
+			Body:   `{"address": {}}`,
+			Status: http.StatusBadRequest,
+			Output: `{"error":"validation failed","fields":[{"path":"name","tag":"required"},{"path":"address.street","tag":"required"}]}` + "\n",
+		},
+		"malformed": {
+			Body:   `[]`,
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				person, ok := FromContext[Person](r.Context())
+				presence := reflect.ValueOf(PresenceFromContext(r.Context())).Elem()
+				_, _ = fmt.Fprint(w, person.Name, " ", ok, " ", !presence.FieldByName("Email").IsNil())
+			})
+			handler := Middleware[Person](validator.New())(next)
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(testData.Body)))
+
+			// Assert
+			assert.Equal(t, testData.Status, w.Code)
+			if testData.Output != "" {
+				assert.Equal(t, testData.Output, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFromContext_Empty(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	// Act
+	_, ok := FromContext[Person](context.Background())
+	presence := PresenceFromContext(context.Background())
+
+	// Assert
+	assert.False(t, ok)
+	assert.Nil(t, presence)
+}
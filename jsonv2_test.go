@@ -0,0 +1,61 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package nullify
+
+import (
+	"encoding/json/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJsonV2Options_Marshal(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Age   int    `json:"age"`
+	}
+
+	p := Nullify(Person{}, JsonV2Options...)
+	if err := json.Unmarshal([]byte(`{"name": ""}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	b, err := json.Marshal(p)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":""}`, string(b))
+}
+
+func TestJsonV2Options_Unmarshal(t *testing.T) {
+	type Person struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Age   int    `json:"age"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected map[string]any
+	}{
+		"present":        {Payload: `{"name": "alice", "age": 0}`, Expected: map[string]any{"Name": "alice", "Age": 0}},
+		"null":           {Payload: `{"name": null}`, Expected: map[string]any{}},
+		"case-sensitive": {Payload: `{"NAME": "alice"}`, Expected: map[string]any{}},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{}, JsonV2Options...)
+
+			// Act
+			err := json.Unmarshal([]byte(testData.Payload), p, json.RejectUnknownMembers(false))
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testData.Expected, plainOf(p))
+		})
+	}
+}
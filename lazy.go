@@ -0,0 +1,178 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Lazy is a JSON object decoded against the nullified type of a prototype, where the Go value of each field is
+// only materialized when it is first accessed. The payload is tokenized once by DecodeLazy, such that requests
+// that are rejected early (e.g. based on which fields are present) do not pay for decoding every value.
+type Lazy struct {
+	raw    map[string]json.RawMessage
+	keys   []string                // keys of raw in document order
+	fields []encodedField          // fields of the nullified struct, including those promoted from embedded structs
+	byName map[string]encodedField // fields by json name
+	value  reflect.Value
+	done   map[string]bool
+}
+
+// DecodeLazy records the raw JSON value of every field present in data without decoding it. The prototype must
+// be a struct (or pointer to struct), options are passed to Nullify. Fields of embedded structs without a json name
+// are fields of their parent, like encoding/json does.
+func DecodeLazy(data []byte, prototype any, options ...option) (*Lazy, error) {
+	value := reflect.ValueOf(newNullified(prototype, options...))
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullify: prototype must be a struct, got %T", prototype)
+	}
+
+	raw, keys, err := decodeObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := encodedFields(value.Elem().Type(), newConfig(options...))
+	byName := make(map[string]encodedField, len(fields))
+	for _, field := range fields {
+		byName[field.name] = field
+	}
+
+	return &Lazy{raw: raw, keys: keys, fields: fields, byName: byName, value: value, done: make(map[string]bool)}, nil
+}
+
+// Has returns true if the field with the json name was present in the payload, without decoding it
+func (l *Lazy) Has(name string) bool {
+	_, ok := l.lookup(name)
+	return ok
+}
+
+// Field decodes the field with the json name if it has not been decoded yet and returns its nullified value,
+// e.g. a *string. A field that was not present in the payload returns a nil pointer.
+func (l *Lazy) Field(name string) (any, error) {
+	field, ok := l.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("nullify: unknown field %q", name)
+	}
+
+	if err := l.materialize(field); err != nil {
+		return nil, err
+	}
+	if value, ok := field.value(l.value.Elem()); ok {
+		return value.Interface(), nil
+	}
+	return reflect.Zero(field.field.Type).Interface(), nil // promoted from an embedded struct that is not set
+}
+
+// Value decodes all remaining fields and returns the nullified instance, as if the payload was passed to
+// json.Unmarshal
+func (l *Lazy) Value() (any, error) {
+	for _, field := range l.fields {
+		if err := l.materialize(field); err != nil {
+			return nil, err
+		}
+	}
+	return l.value.Interface(), nil
+}
+
+// materialize decodes the raw value of field into the nullified instance, allocating the embedded structs it is
+// promoted from
+func (l *Lazy) materialize(field encodedField) error {
+	if l.done[field.name] {
+		return nil
+	}
+
+	if raw, ok := l.lookup(field.name); ok {
+		v := l.value.Elem()
+		for i, index := range field.index {
+			if i > 0 && v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+			v = v.Field(index)
+		}
+		_, opts, _ := strings.Cut(field.field.Tag.Get("json"), ",")
+		if hasOption(opts, "string") && isQuotableField(field.field.Type) && string(raw) != "null" {
+			// the json encoding of the value inside a string, like encoding/json does for `,string`
+			var quoted string
+			if err := json.Unmarshal(raw, &quoted); err != nil {
+				return fmt.Errorf("nullify: field %q: invalid use of ,string: %w", field.name, err)
+			}
+			raw = json.RawMessage(quoted)
+		}
+		if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+			return fmt.Errorf("nullify: field %q: %w", field.name, err)
+		}
+	}
+	l.done[field.name] = true
+	return nil
+}
+
+// lookup returns the raw value for the json name. Like encoding/json does, each key is matched to the field with
+// exactly that name or else to the first field whose name matches case-insensitively, and of the keys matched to the
+// same field the last one in document order wins.
+func (l *Lazy) lookup(name string) (json.RawMessage, bool) {
+	if _, ok := l.byName[name]; !ok {
+		raw, ok := l.raw[name]
+		return raw, ok
+	}
+
+	for i := len(l.keys) - 1; i >= 0; i-- {
+		if l.target(l.keys[i]) == name {
+			return l.raw[l.keys[i]], true
+		}
+	}
+	return nil, false
+}
+
+// target returns the json name of the field the key is decoded into, empty if there is none
+func (l *Lazy) target(key string) string {
+	if _, ok := l.byName[key]; ok {
+		return key
+	}
+	for _, field := range l.fields {
+		if strings.EqualFold(key, field.name) {
+			return field.name
+		}
+	}
+	return ""
+}
+
+// decodeObject returns the raw value of every member of the json object data and the keys in document order (a key
+// that occurs more than once is listed at each occurrence, its raw value is the last one). Data that is not an object
+// is reported like json.Unmarshal into a map does.
+func decodeObject(data []byte) (map[string]json.RawMessage, []string, error) {
+	var raw map[string]json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return raw, nil, json.Unmarshal(data, &raw)
+	}
+
+	raw = make(map[string]json.RawMessage)
+	var keys []string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		key := token.(string) // object keys are always strings
+		raw[key] = value
+		keys = append(keys, key)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, nil, json.Unmarshal(data, &raw) // data after the object
+	}
+	return raw, keys, nil
+}
@@ -0,0 +1,231 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeLazy(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Ignored string   `json:"-"`
+	}
+
+	data := []byte(`{"name": "alice", "AGE": 30, "tags": "invalid"}`)
+
+	// Act
+	lazy, err := DecodeLazy(data, Person{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, lazy.Has("name"))
+	assert.True(t, lazy.Has("age"))
+	assert.True(t, lazy.Has("tags"))
+
+	name, err := lazy.Field("name")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", *name.(*string))
+
+	age, err := lazy.Field("age")
+	assert.Nil(t, err)
+	assert.Equal(t, 30, *age.(*int))
+
+	_, err = lazy.Field("tags")
+	assert.ErrorContains(t, err, `nullify: field "tags": json: cannot unmarshal string`)
+
+	_, err = lazy.Field("Ignored")
+	assert.EqualError(t, err, `nullify: unknown field "Ignored"`)
+}
+
+func TestDecodeLazy_Value(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Ignored string   `json:"-"`
+	}
+
+	lazy, err := DecodeLazy([]byte(`{"name": "alice"}`), &Person{})
+	assert.Nil(t, err)
+	assert.False(t, lazy.Has("age"))
+
+	// Act
+	value, err := lazy.Value()
+
+	// Assert
+	assert.Nil(t, err)
+	var person Person
+	assert.Nil(t, CopyMatching(value, &person))
+	assert.Equal(t, Person{Name: "alice"}, person)
+}
+
+func TestDecodeLazy_Embedded(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		CreatedBy string `json:"createdBy"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Document struct {
+		*Audit
+		Base
+		Title string `json:"title"`
+	}
+
+	data := []byte(`{"id": "a", "createdBy": "bob", "title": "x"}`)
+
+	// Act
+	lazy, err := DecodeLazy(data, Document{})
+
+	// Assert
+	assert.NoError(t, err)
+	id, err := lazy.Field("id")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", *id.(*string))
+
+	value, err := lazy.Value()
+	assert.NoError(t, err)
+	var document Document
+	assert.NoError(t, CopyMatching(value, &document))
+	expected := Document{Audit: &Audit{CreatedBy: "bob"}, Base: Base{ID: "a"}, Title: "x"}
+	assert.Equal(t, expected, document)
+}
+
+func TestDecodeLazy_EmbeddedNotSet(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		CreatedBy string `json:"createdBy"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Document struct {
+		*Audit
+		Base
+		Title string `json:"title"`
+	}
+
+	lazy, err := DecodeLazy([]byte(`{"title": "x"}`), Document{})
+	assert.NoError(t, err)
+
+	// Act
+	createdBy, err := lazy.Field("createdBy")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, createdBy)
+	assert.False(t, lazy.Has("createdBy"))
+}
+
+func TestDecodeLazy_CaseInsensitive(t *testing.T) {
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Ignored string   `json:"-"`
+	}
+
+	tests := map[string]struct {
+		Data     string
+		Expected string
+	}{
+		"last folded key":        {Data: `{"NAME": "c", "Name": "b", "nAME": "a"}`, Expected: "a"},
+		"folded key after exact": {Data: `{"name": "a", "NAME": "b"}`, Expected: "b"},
+		"exact key after folded": {Data: `{"NAME": "a", "name": "b"}`, Expected: "b"},
+		"duplicate key":          {Data: `{"name": "a", "Name": "b", "name": "c"}`, Expected: "c"},
+		"folded key only":        {Data: `{"Name": "a"}`, Expected: "a"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			lazy, err := DecodeLazy([]byte(testData.Data), Person{})
+			assert.NoError(t, err)
+			var expected Person
+			assert.NoError(t, json.Unmarshal([]byte(testData.Data), &expected))
+
+			// Act
+			name, err := lazy.Field("name")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, *name.(*string))
+			assert.Equal(t, expected.Name, *name.(*string)) // like encoding/json
+		})
+	}
+}
+
+func TestDecodeLazy_StringOption(t *testing.T) {
+	// Arrange
+	type Order struct {
+		ID    int64  `json:"id,string"`
+		Label string `json:"label,string"`
+	}
+	lazy, err := DecodeLazy([]byte(`{"id": "42", "label": "\"x\""}`), Order{})
+	assert.NoError(t, err)
+
+	// Act
+	id, idErr := lazy.Field("id")
+	label, labelErr := lazy.Field("label")
+
+	// Assert
+	assert.NoError(t, idErr)
+	assert.Equal(t, int64(42), *id.(*int64))
+	assert.NoError(t, labelErr)
+	assert.Equal(t, "x", *label.(*string))
+}
+
+func TestDecodeLazy_ValueResult(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Ignored string   `json:"-"`
+	}
+
+	// Act
+	lazy, err := DecodeLazy([]byte(`{"name": "alice"}`), Person{}, ValueResult{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	name, err := lazy.Field("name")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", *name.(*string))
+}
+
+func TestDecodeLazy_Errors(t *testing.T) {
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Tags    []string `json:"tags"`
+		Ignored string   `json:"-"`
+	}
+
+	tests := map[string]struct {
+		Data         string
+		Prototype    any
+		ErrorMessage string
+	}{
+		"not a struct":  {Data: `{}`, Prototype: "", ErrorMessage: "nullify: prototype must be a struct, got string"},
+		"not an object": {Data: `[]`, Prototype: Person{}, ErrorMessage: "json: cannot unmarshal array"},
+		"trailing data": {Data: `{} {}`, Prototype: Person{}, ErrorMessage: "after top-level value"},
+		"invalid":       {Data: `{"name": }`, Prototype: Person{}, ErrorMessage: "looking for beginning of value"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := DecodeLazy([]byte(testData.Data), testData.Prototype)
+
+			// Assert
+			assert.ErrorContains(t, err, testData.ErrorMessage)
+		})
+	}
+}
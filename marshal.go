@@ -0,0 +1,294 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// encodedFieldsCache caches the result of encodedFields for configs without tag priorities by nullified struct type
+var encodedFieldsCache sync.Map // reflect.Type -> []encodedField
+
+// Marshal returns the JSON encoding of the nullified value like json.Marshal does, but leaves out the struct fields
+// that are not set (nil pointers, slices and maps) regardless of whether their json tag contains omitempty, e.g. to
+// forward validated input downstream without flooding it with nulls. Fields set to an empty value are kept, as
+// encoding/json does for non-nil pointers, omitempty only applies to fields that are not pointers.
+//
+// Fields are written in declaration order, map keys are sorted and embedded structs without a json name are
+// flattened into their parent, like encoding/json does. Nil elements of slices and maps are null. Values that are
+// not nullified structs, slices or maps (e.g. time.Time) are encoded by encoding/json.
+func Marshal(nullified any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := marshalValue(&buf, reflect.ValueOf(nullified), ""); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalValue writes the json encoding of v to buf
+func marshalValue(buf *bytes.Buffer, v reflect.Value, path string) error {
+	v, ok := indirect(v)
+	if !ok {
+		buf.WriteString("null")
+		return nil
+	}
+
+	switch {
+	case v.Type().Implements(jsonMarshaler):
+		// encoded by encoding/json below
+	case isNullifiedStruct(v.Type()):
+		buf.WriteByte('{')
+		if err := marshalFields(buf, v, path); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8, v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := marshalValue(buf, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case v.Kind() == reflect.Map:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := canonicalKey(iter.Key())
+			if err != nil {
+				return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+			}
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(key)
+			buf.Write(name)
+			buf.WriteByte(':')
+			if err := marshalValue(buf, values[key], fmt.Sprintf("%s[%s]", path, key)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+
+	if !v.CanInterface() {
+		buf.WriteString("null")
+		return nil
+	}
+	b, err := json.Marshal(addressable(v).Interface()) // pointer receivers, e.g. big.Int
+	if err != nil {
+		return fmt.Errorf("nullify: %s: %w", pathOrRoot(path), err)
+	}
+	buf.Write(b)
+	return nil
+}
+
+// marshalFields writes the set fields of the nullified struct v to buf as object members
+func marshalFields(buf *bytes.Buffer, v reflect.Value, path string) error {
+	more := false
+	for _, encoded := range encodedFields(v.Type(), config{}) {
+		field, ok := encoded.value(v)
+		if !ok {
+			continue
+		}
+		value, ok := indirect(field)
+		if !ok || isNil(value) {
+			continue
+		}
+
+		_, opts, _ := strings.Cut(encoded.field.Tag.Get("json"), ",")
+		if hasOption(opts, "omitempty") && encoded.field.Type.Kind() != reflect.Pointer && isEmptyJson(value) {
+			continue
+		}
+
+		if more {
+			buf.WriteByte(',')
+		}
+		more = true
+		key, _ := json.Marshal(encoded.name)
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		fieldPath := joinPath(path, encoded.path)
+		if !hasOption(opts, "string") || !isQuotable(value.Kind()) {
+			if err := marshalValue(buf, value, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var quoted bytes.Buffer
+		if err := marshalValue(&quoted, value, fieldPath); err != nil {
+			return err
+		}
+		quotedJson, _ := json.Marshal(quoted.String())
+		buf.Write(quotedJson)
+	}
+	return nil
+}
+
+// encodedField is a field of a nullified struct that is encoded as an object member, possibly promoted from an
+// embedded struct
+type encodedField struct {
+	name   string              // json name (or name according to the tag priority) of the field
+	path   string              // dotted Go path of the field, including the embedded structs it is promoted from
+	index  []int               // index sequence of the field, see reflect.Value.FieldByIndex
+	field  reflect.StructField // the field itself
+	tagged bool                // whether the name is given by a tag
+}
+
+// value returns the field of the nullified struct v, false if an embedded struct it is promoted from is not set
+func (f encodedField) value(v reflect.Value) (reflect.Value, bool) {
+	for i, index := range f.index {
+		if i > 0 {
+			var ok bool
+			if v, ok = indirect(v); !ok {
+				return v, false
+			}
+		}
+		v = v.Field(index)
+	}
+	return v, true
+}
+
+// encodedFields returns the fields of the nullified struct type t that encoding/json encodes as object members in
+// field order. Embedded structs without a name are resolved like encoding/json does: of the fields with the same
+// name the shallowest one wins, a tagged field wins over untagged fields at the same depth and names that remain
+// ambiguous are dropped.
+func encodedFields(t reflect.Type, cfg config) []encodedField {
+	if len(cfg.tagPriority) == 0 {
+		if fields, ok := encodedFieldsCache.Load(t); ok {
+			return fields.([]encodedField)
+		}
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+		path  string
+	}
+
+	var fields []encodedField
+	next := []embedded{{typ: t}}
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current, count := next, map[reflect.Type]int{}
+		next = nil
+		for _, e := range current {
+			count[e.typ]++
+		}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				field := e.typ.Field(i)
+				name, ok := fieldName(field, cfg)
+				if !ok || !field.IsExported() {
+					continue
+				}
+				index := append(slices.Clip(e.index), i)
+				path := joinPath(e.path, field.Name)
+
+				_, prioritized := priorityName(field, cfg)
+				tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				tagged := prioritized || tagName != ""
+				if !tagged && field.Anonymous && isNullifiedStruct(indirectType(field.Type)) {
+					next = append(next, embedded{typ: indirectType(field.Type), index: index, path: path})
+					continue
+				}
+
+				encoded := encodedField{name: name, path: path, index: index, field: field, tagged: tagged}
+				fields = append(fields, encoded)
+				if count[e.typ] > 1 {
+					// embedded more than once at the same depth, such that its fields annihilate each other
+					fields = append(fields, encoded)
+				}
+			}
+		}
+	}
+
+	// of the fields sharing a name, keep the dominant one (if any)
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].index) != len(fields[i+1].index) || fields[i].tagged != fields[i+1].tagged {
+			dominant = append(dominant, fields[i])
+		}
+		i = j
+	}
+	slices.SortFunc(dominant, func(a, b encodedField) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	if len(cfg.tagPriority) == 0 {
+		encodedFieldsCache.Store(t, dominant)
+	}
+	return dominant
+}
+
+// isEmptyJson returns true if encoding/json considers v empty for omitempty
+func isEmptyJson(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isQuotable returns true if the `,string` json option applies to values of kind
+func isQuotable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMarshal(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name     string            `json:"name"`
+		Bio      string            `json:"bio,omitempty"`
+		Age      int               `json:"age,string"`
+		Code     string            `json:"code,string"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Birthday time.Time         `json:"birthday"`
+		Address  Address           `json:"address"`
+		Note     string            `json:"note,omitempty" nullify:"-"`
+		Secret   string            `json:"-"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected string
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: `{}`,
+		},
+		"set fields": {
+			Payload:  `{"name": "alice", "id": "1", "address": {"street": "Main St"}, "tags": ["b", null]}`,
+			Expected: `{"id":"1","name":"alice","tags":["b",null],"address":{"street":"Main St"}}`,
+		},
+		"empty values": {
+			Payload:  `{"name": "", "bio": "", "tags": [], "labels": {}, "address": {}, "note": ""}`,
+			Expected: `{"name":"","bio":"","tags":[],"labels":{},"address":{}}`,
+		},
+		"map keys": {
+			Payload:  `{"labels": {"z": "1", "a": null}}`,
+			Expected: `{"labels":{"a":null,"z":"1"}}`,
+		},
+		"string option": {
+			Payload:  `{"age": "42"}`,
+			Expected: `{"age":"42"}`,
+		},
+		"string option on a string": {
+			Payload:  `{"code": "\"<a\\u0001>\""}`,
+			Expected: `{"code":"\"\\u003ca\\u0001\\u003e\""}`,
+		},
+		"leaf with MarshalJSON": {
+			Payload:  `{"birthday": "2000-01-02T10:00:00Z", "note": "hi"}`,
+			Expected: `{"birthday":"2000-01-02T10:00:00Z","note":"hi"}`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			b, err := Marshal(p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, string(b))
+		})
+	}
+}
+
+func TestMarshal_Nil(t *testing.T) {
+	// Act
+	b, err := Marshal(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestMarshal_EmbeddedConflicts(t *testing.T) {
+	type Other struct {
+		ID   string
+		Name string
+	}
+	type Tagged struct {
+		Code string `json:"Name"`
+	}
+	type Untagged struct {
+		ID string
+	}
+	type Shadow struct {
+		ID string
+		Untagged
+	}
+	type Ambiguous struct {
+		Untagged
+		Other
+	}
+	type TaggedWins struct {
+		Other
+		Tagged
+	}
+
+	tests := map[string]struct {
+		Original any
+		Expected string
+	}{
+		"shallowest wins": {
+			Original: &Shadow{ID: "outer", Untagged: Untagged{ID: "inner"}},
+			Expected: `{"ID":"outer"}`,
+		},
+		"ambiguous dropped": {
+			Original: &Ambiguous{
+				Untagged: Untagged{ID: "a"},
+				Other:    Other{ID: "b", Name: "n"},
+			},
+			Expected: `{"Name":"n"}`,
+		},
+		"tagged wins": {
+			Original: &TaggedWins{
+				Other:  Other{ID: "b", Name: "n"},
+				Tagged: Tagged{Code: "c"},
+			},
+			Expected: `{"ID":"b","Name":"c"}`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(testData.Original)
+			if err := CopyMatching(testData.Original, p, MatchFields{Value: MatchGoName}); err != nil {
+				t.Fatal(err)
+			}
+			expected, _ := json.Marshal(testData.Original)
+
+			// Act
+			b, err := Marshal(p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Expected, string(b))
+			assert.Equal(t, string(expected), string(b))
+		})
+	}
+}
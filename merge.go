@@ -0,0 +1,79 @@
+package nullify
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Conflict is a field that was changed differently on both sides of a three-way merge
+type Conflict struct {
+	Path   string // dotted path of Go field names, e.g. Address.City
+	Base   any    // dereferenced value in base, nil if unset
+	Mine   any    // dereferenced value in mine, nil if unset
+	Theirs any    // dereferenced value in theirs, nil if unset
+}
+
+// Merge3 merges the nullified values mine and theirs, which both derive from base, into a new value of the same
+// type. Fields changed on one side (compared with Equal) take the value of that side, fields changed on both sides
+// to the same value take that value and fields changed on both sides to different values keep the value of base
+// and are reported as a Conflict. Nested nullified structs that are set on all sides are merged field by field.
+// The result shares the values of the fields it takes with the inputs.
+func Merge3(base any, mine any, theirs any, options ...option) (any, []Conflict, error) {
+	typ := reflect.TypeOf(base)
+	if typ == nil || typ.Kind() != reflect.Pointer || !isNullifiedStruct(typ.Elem()) {
+		return nil, nil, fmt.Errorf("nullify: base must be a pointer to a nullified struct, got %T", base)
+	}
+	if reflect.TypeOf(mine) != typ || reflect.TypeOf(theirs) != typ {
+		return nil, nil, fmt.Errorf("nullify: cannot merge %T and %T into %T", mine, theirs, base)
+	}
+
+	cfg := newConfig(options...)
+	result := reflect.New(typ.Elem())
+	var conflicts []Conflict
+	merge3(result.Elem(), indirectStruct(base), indirectStruct(mine), indirectStruct(theirs), "", cfg, &conflicts)
+	return result.Interface(), conflicts, nil
+}
+
+// merge3 merges the fields of the nullified structs mine and theirs into dst
+func merge3(dst, base, mine, theirs reflect.Value, path string, cfg config, conflicts *[]Conflict) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := joinPath(path, field.Name)
+		b, m, t := base.Field(i), mine.Field(i), theirs.Field(i)
+		if field.Type.Kind() == reflect.Pointer && isNullifiedStruct(field.Type.Elem()) && !b.IsNil() && !m.IsNil() && !t.IsNil() {
+			nested := reflect.New(field.Type.Elem())
+			merge3(nested.Elem(), b.Elem(), m.Elem(), t.Elem(), fieldPath, cfg, conflicts)
+			dst.Field(i).Set(nested)
+			continue
+		}
+
+		mineChanged, theirsChanged := !equal(b, m, cfg), !equal(b, t, cfg)
+		switch {
+		case mineChanged && theirsChanged && !equal(m, t, cfg):
+			dst.Field(i).Set(b)
+			baseValue, _ := plain(b)
+			mineValue, _ := plain(m)
+			theirsValue, _ := plain(t)
+			*conflicts = append(*conflicts, Conflict{Path: fieldPath, Base: baseValue, Mine: mineValue, Theirs: theirsValue})
+		case mineChanged:
+			dst.Field(i).Set(m)
+		case theirsChanged:
+			dst.Field(i).Set(t)
+		default:
+			dst.Field(i).Set(b)
+		}
+	}
+}
+
+// indirectStruct returns the struct the pointer v points to, or the zero value of the struct if v is nil
+func indirectStruct(v any) reflect.Value {
+	value := reflect.ValueOf(v)
+	if value.IsNil() {
+		return reflect.New(value.Type().Elem()).Elem()
+	}
+	return value.Elem()
+}
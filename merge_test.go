@@ -0,0 +1,159 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMerge3(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Document struct {
+		Title   string   `json:"title"`
+		Body    string   `json:"body"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	base := `{"title": "draft", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`
+
+	tests := map[string]struct {
+		Mine      string
+		Theirs    string
+		Expected  string
+		Conflicts []Conflict
+	}{
+		"unchanged": {
+			Mine:     base,
+			Theirs:   base,
+			Expected: base,
+		},
+		"changed on one side each": {
+			Mine:     `{"title": "final", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+			Theirs:   `{"title": "draft", "body": "hello", "tags": ["a", "b"], "address": {"street": "Main St", "city": "Shelbyville"}}`,
+			Expected: `{"title": "final", "body": "hello", "tags": ["a", "b"], "address": {"street": "Main St", "city": "Shelbyville"}}`,
+		},
+		"changed on both sides to the same value": {
+			Mine:     `{"title": "final", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+			Theirs:   `{"title": "final", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+			Expected: `{"title": "final", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+		},
+		"conflict": {
+			Mine:     `{"title": "mine", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+			Theirs:   `{"title": "theirs", "body": "hello", "tags": ["a"], "address": {"street": "Elm St", "city": "Springfield"}}`,
+			Expected: `{"title": "draft", "body": "hello", "tags": ["a"], "address": {"street": "Elm St", "city": "Springfield"}}`,
+			Conflicts: []Conflict{
+				{Path: "Title", Base: "draft", Mine: "mine", Theirs: "theirs"},
+			},
+		},
+		"unset on one side": {
+			Mine:     `{"title": "draft", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+			Theirs:   base,
+			Expected: `{"title": "draft", "tags": ["a"], "address": {"street": "Main St", "city": "Springfield"}}`,
+		},
+		"nested conflict": {
+			Mine:     `{"title": "draft", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Capital City"}}`,
+			Theirs:   `{"title": "draft", "body": "hello", "tags": ["a"], "address": {"street": "Main St", "city": "Shelbyville"}}`,
+			Expected: base,
+			Conflicts: []Conflict{
+				{Path: "Address.City", Base: "Springfield", Mine: "Capital City", Theirs: "Shelbyville"},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			decode := func(payload string) any {
+				p := Nullify(Document{}, JsonOptions...)
+				if err := json.Unmarshal([]byte(payload), p); err != nil {
+					t.Fatal(err)
+				}
+				return p
+			}
+
+			// Act
+			merged, conflicts, err := Merge3(decode(base), decode(testData.Mine), decode(testData.Theirs))
+
+			// Assert
+			assert.Nil(t, err)
+			assert.True(t, Equal(decode(testData.Expected), merged), Dump(merged))
+			assert.Equal(t, testData.Conflicts, conflicts)
+		})
+	}
+}
+
+func TestMerge3_UnchangedTime(t *testing.T) {
+	// Arrange
+	type Event struct {
+		Name string    `json:"name"`
+		At   time.Time `json:"at"`
+	}
+	decode := func(payload string) any {
+		p := Nullify(Event{}, JsonOptions...)
+		if err := json.Unmarshal([]byte(payload), p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	base := decode(`{"name": "draft", "at": "2024-01-02T03:04:05Z"}`)
+	mine := decode(`{"name": "final", "at": "2024-01-02T03:04:05Z"}`)
+	theirs := decode(`{"name": "draft", "at": "2024-01-02T03:04:05Z"}`)
+
+	// Act
+	merged, conflicts, err := Merge3(base, mine, theirs)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.True(t, Equal(mine, merged), Dump(merged))
+}
+
+func TestMerge3_Errors(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Document struct {
+		Title   string   `json:"title"`
+		Body    string   `json:"body"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+
+	p := Nullify(Document{})
+
+	tests := map[string]struct {
+		Base         any
+		Mine         any
+		Theirs       any
+		ErrorMessage string
+	}{
+		"not nullified": {
+			Base:         Document{},
+			Mine:         Document{},
+			Theirs:       Document{},
+			ErrorMessage: "nullify: base must be a pointer to a nullified struct, got nullify.Document",
+		},
+		"different types": {
+			Base:         p,
+			Mine:         p,
+			Theirs:       Nullify(Address{}),
+			ErrorMessage: "nullify: cannot merge",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, _, err := Merge3(testData.Base, testData.Mine, testData.Theirs)
+
+			// Assert
+			assert.ErrorContains(t, err, testData.ErrorMessage)
+		})
+	}
+}
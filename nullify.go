@@ -1,8 +1,15 @@
 package nullify
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"reflect"
+	"slices"
+	"strconv"
+	"time"
 )
 
 // Nullify returns the pointer version of any input, e.g. string becomes *string, int becomes *int
@@ -18,7 +25,10 @@ import (
 //	   Name *string
 //	}
 //
-// with `p := Person{}`, Nullify(p) returns a pointer to Person.
+// with `p := Person{}`, Nullify(p) returns a pointer to Person (see ValueResult for the value form).
+//
+// Individual struct fields can be controlled with the `nullify` tag: `nullify:"-"` leaves the type of the field
+// untouched and `nullify:"leaf"` pointerizes the type of the field without decomposing it.
 //
 // This is especially useful in e.g. validating JSON input, see example.
 func Nullify(obj any, options ...option) any {
@@ -27,24 +37,107 @@ func Nullify(obj any, options ...option) any {
 		return nil // guard for nil interface{}
 	}
 
-	// default config
-	cfg := config{
-		bytesAsString:        false,
-		nullifyArrayElem:     true,
-		nullifySliceElem:     true,
-		nullifyMapElem:       true,
-		nullifyMapKey:        true,
-		nullifyMarshalJson:   false,
-		nullifyUnmarshalJson: false,
+	instance := reflect.ValueOf(newNullified(obj, options...))
+	if newConfig(options...).valueResult {
+		return instance.Elem().Interface()
 	}
+	return instance.Interface()
+}
 
-	// process options
-	for _, opt := range options {
-		cfg = opt.update(cfg)
+// newNullified returns a pointer to a new instance of the nullified version of obj regardless of ValueResult, such
+// that the helpers built on Nullify can decode into it. It returns nil if obj is the nil interface.
+func newNullified(obj any, options ...option) any {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil
+	}
+
+	typ := nullifiedType(typeOf, options...)
+	countInstance(typeOf)
+	return reflect.New(typ.Elem()).Interface()
+}
+
+// TypeOf returns the type of the result of Nullify for obj, e.g. to embed it in other dynamically built types with
+// reflect.StructOf. Combine with ValueResult to obtain the struct type rather than a pointer to it. It returns nil if
+// obj is the nil interface.
+func TypeOf(obj any, options ...option) reflect.Type {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil
 	}
 
-	val := ptr(typeOf, cfg)
-	return reflect.New(val.Elem()).Interface()
+	typ := nullifiedType(typeOf, options...)
+	if newConfig(options...).valueResult {
+		return typ.Elem()
+	}
+	return typ
+}
+
+// NullifyE is Nullify returning an error rather than a surprising type or a panic, e.g. when options conflict
+// (BytesAsString with a WithTypeOverride for []byte) or a type is recursive without OnRecursion
+func NullifyE(obj any, options ...option) (nullified any, err error) {
+	if reflect.TypeOf(obj) == nil {
+		return nil, fmt.Errorf("nullify: cannot nullify nil")
+	}
+	if err := newConfig(options...).validate(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			nullified, err = nil, fmt.Errorf("nullify: cannot nullify %T: %v", obj, r)
+		}
+	}()
+	return Nullify(obj, options...), nil
+}
+
+// NullifyInto places the zeroed nullified version of src into dst instead of allocating a new instance where
+// possible. dst is either a *any, which is reused if it already holds an instance of the nullified type, or a
+// non-nil pointer of the nullified type (e.g. the result of an earlier call to Nullify) which is zeroed in place.
+func NullifyInto(dst any, src any, options ...option) error {
+	typeOf := reflect.TypeOf(src)
+	if typeOf == nil {
+		return fmt.Errorf("nullify: cannot nullify nil")
+	}
+	typ := nullifiedType(typeOf, options...)
+
+	if slot, ok := dst.(*any); ok && slot != nil {
+		if reflect.TypeOf(*slot) != typ || reflect.ValueOf(*slot).IsNil() {
+			*slot = reflect.New(typ.Elem()).Interface()
+			return nil
+		}
+		dst = *slot
+	}
+
+	if reflect.TypeOf(dst) != typ || reflect.ValueOf(dst).IsNil() {
+		return fmt.Errorf("nullify: cannot nullify %T into %T", src, dst)
+	}
+	reflect.ValueOf(dst).Elem().SetZero()
+	return nil
+}
+
+// nullifiedType returns the nullified version of t, which is always a pointer type. Results are cached for
+// comparable options, see Compile.
+func nullifiedType(t reflect.Type, options ...option) reflect.Type {
+	key, cacheable := newCompiledKey(t, options)
+	if typ, ok := compiled.Load(key); cacheable && ok {
+		stats.cacheHits.Add(1)
+		return typ.(reflect.Type)
+	}
+
+	start := time.Now()
+	cfg := newConfig(options...)
+	cfg.memo = map[reflect.Type]reflect.Type{}
+	cfg.substitutions = new(int)
+	typ := ptr(t, cfg)
+	if typ.Kind() != reflect.Pointer {
+		typ = reflect.PointerTo(typ) // e.g. a slice with BareContainers
+	}
+	if cacheable {
+		compiled.Store(key, typ)
+	}
+	recordCompile(CompileEvent{Original: t, Type: typ, Duration: time.Since(start), Cached: cacheable})
+	return typ
 }
 
 // JsonOptions is a curated list of options that can be used for json.Marshal, json.Unmarshal.
@@ -57,6 +150,49 @@ var JsonOptions = []option{
 	NullifyArrayElem{Value: false},
 	NullifyMarshalJson{Value: false},
 	NullifyUnmarshalJson{Value: false},
+	SafeMapKeys{Value: true},
+	SkipUnserializable{Value: true},
+}
+
+// JsonV2Options is JsonOptions for encoding/json/v2, which is case-sensitive and omits empty JSON values for
+// omitempty: OmitZero is added such that unset fields are omitted when marshalling while fields set to an empty
+// value are kept. Use by spreading it onto the nullify function: `Nullify(t, JsonV2Options...)`
+var JsonV2Options = append(slices.Clip(JsonOptions), OmitZero{Value: true})
+
+// ValidatorOptions is JsonOptions with OmitNil, such that go-playground/validator only runs the rules of optional
+// fields when they are present. Use by spreading it onto the nullify function: `Nullify(t, ValidatorOptions...)`
+var ValidatorOptions = append(slices.Clip(JsonOptions), OmitNil{Value: true})
+
+// ProtoJsonOptions is a curated list of options for validating the protojson representation of structs generated
+// from protobuf, e.g. in front of grpc-gateway: see Protobuf. Bytes are base64 strings and containers are not
+// element-nullified like with JsonOptions. Use by spreading it onto the nullify function: `Nullify(t, ProtoJsonOptions...)`
+var ProtoJsonOptions = append(slices.Clip(JsonOptions), Protobuf{Value: true})
+
+// SqlOptions is a curated list of options for scanning database rows into nullified structs, e.g. with
+// database/sql, sqlx.StructScan or pgx. Scanning a NULL column leaves the field nil. `db` tags are retained,
+// containers are not element-nullified and sql.Scanner / driver.Valuer types (e.g. sql.NullString) and
+// time.Time are kept as leaves. Use by spreading it onto the nullify function: `Nullify(t, SqlOptions...)`
+var SqlOptions = []option{
+	BytesAsString{Value: false},
+	NullifyMapKey{Value: false},
+	NullifyMapElem{Value: false},
+	NullifySliceElem{Value: false},
+	NullifyArrayElem{Value: false},
+	NullifyMarshalJson{Value: false},
+	NullifyUnmarshalJson{Value: false},
+	NullifySqlScanner{Value: false},
+}
+
+// NumericOptions keeps the arbitrary precision numbers of math/big (big.Int, big.Float and big.Rat) as leaves rather
+// than decomposing them into structs without exported fields. Their text and json methods have pointer receivers,
+// such that Marshal, MarshalCanonical and CopyMatching use those through a pointer and copy them by their text
+// representation. Types like shopspring/decimal.Decimal marshal themselves by value and are leaves already unless
+// NullifyMarshalJson is set, use WithLeaf for those. Use by spreading it after other options:
+// `Nullify(t, append(slices.Clip(JsonOptions), NumericOptions...)...)`
+var NumericOptions = []option{
+	WithLeaf(reflect.TypeOf(big.Int{})),
+	WithLeaf(reflect.TypeOf(big.Float{})),
+	WithLeaf(reflect.TypeOf(big.Rat{})),
 }
 
 // config determines the behavior of the ptr function
@@ -68,6 +204,63 @@ type config struct {
 	nullifyMapKey        bool
 	nullifyMarshalJson   bool
 	nullifyUnmarshalJson bool
+	nullifySqlScanner    bool
+	keepNamedBytes       bool
+	omitEmpty            bool
+	omitZero             bool
+	validateRequired     bool
+	omitNil              bool
+	nilAsZero            bool
+	flattenEmbedded      bool
+	safeMapKeys          bool
+	stringMapKeys        bool
+	jsonNumbers          bool
+	nullifyInterfaceElem bool
+	skipUnserializable   bool
+	bareContainers       bool
+	nullifyStructFields  bool
+	valueResult          bool
+	recursion            Recursion
+	byteEncoding         ByteEncoding
+	matching             Matching
+	protobuf             bool
+	stripTags            []string
+	tagPriority          []string
+	tagRemaps            []RemapTag
+	fieldTransforms      []func(reflect.StructField) reflect.StructField
+	fieldNameFuncs       []func(string) string
+	fieldOptions         []fieldOptions
+	transformers         []Transformer
+	interfaceImpls       map[reflect.Type]reflect.Type
+	typeOverrides        map[reflect.Type]reflect.Type
+	path                 string                        // dotted path of the struct field currently being nullified
+	memo                 map[reflect.Type]reflect.Type // results of ptr within a single call to Nullify
+	substitutions        *int                          // recursive references substituted so far by recursive
+	visiting             []reflect.Type                // struct types currently being nullified, to detect cycles
+	copyOnWrite          bool                          // CopyMatching replaces rather than writes through pointers in dst
+}
+
+// newConfig returns the default config updated with the provided options
+func newConfig(options ...option) config {
+	// default config
+	cfg := config{
+		bytesAsString:        false,
+		nullifyArrayElem:     true,
+		nullifySliceElem:     true,
+		nullifyMapElem:       true,
+		nullifyMapKey:        true,
+		nullifyMarshalJson:   false,
+		nullifyUnmarshalJson: false,
+		nullifySqlScanner:    true,
+		nullifyStructFields:  true,
+	}
+
+	// process options
+	for _, opt := range options {
+		cfg = opt.update(cfg)
+	}
+
+	return cfg
 }
 
 // option functionally updates the ptr function
@@ -75,8 +268,14 @@ type option interface {
 	update(cfg config) config
 }
 
+// Option is any of the options accepted by Nullify and the helpers, such that packages building on nullify can
+// accept and forward them, e.g. schemagen.FromJSONSchema
+type Option = option
+
 // BytesAsString if true (default false) processes []uint8, []byte as string
-// this is especially useful in json.Marshal, json.Unmarshal cases
+// this is especially useful in json.Marshal, json.Unmarshal cases. Byte arrays (e.g. [16]byte) are kept as leaves
+// instead, as encoding/json does not encode them as base64. Named byte arrays such as uuid.UUID, which typically
+// implement encoding.TextUnmarshaler, are always kept as leaves.
 type BytesAsString struct {
 	Value bool
 }
@@ -146,14 +345,513 @@ func (o NullifyUnmarshalJson) update(cfg config) config {
 	return cfg
 }
 
+// NullifySqlScanner if true (default true) nullifies elements which implement the sql.Scanner or driver.Valuer
+// interface, if false such types (e.g. sql.NullString) are kept as leaves
+type NullifySqlScanner struct {
+	Value bool
+}
+
+func (o NullifySqlScanner) update(cfg config) config {
+	cfg.nullifySqlScanner = o.Value
+	return cfg
+}
+
+// KeepNamedBytes if true (default false) keeps named types whose underlying type is []byte (e.g. json.RawMessage
+// or `type Hash []byte`) intact as a pointer to the named type, instead of converting them with BytesAsString or
+// decomposing them into a slice of pointers
+type KeepNamedBytes struct {
+	Value bool
+}
+
+func (o KeepNamedBytes) update(cfg config) config {
+	cfg.keepNamedBytes = o.Value
+	return cfg
+}
+
+// OmitEmpty if true (default false) appends `,omitempty` to the json tag of every pointerized struct field,
+// such that unset fields are left out when marshalling the nullified value instead of serializing as null
+type OmitEmpty struct {
+	Value bool
+}
+
+func (o OmitEmpty) update(cfg config) config {
+	cfg.omitEmpty = o.Value
+	return cfg
+}
+
+// OmitZero if true (default false) appends `,omitzero` to the json tag of every pointerized struct field, such that
+// unset fields are left out when marshalling the nullified value. Unlike omitempty, which encoding/json/v2 applies
+// to empty JSON values (e.g. ""), omitzero only omits nil pointers and keeps fields that are set to an empty value.
+type OmitZero struct {
+	Value bool
+}
+
+func (o OmitZero) update(cfg config) config {
+	cfg.omitZero = o.Value
+	return cfg
+}
+
+// ValidateRequired if true (default false) rewrites the go-playground/validator `validate` tags of pointerized
+// fields: fields without a validate tag that were not a pointer in the original type get `required`, and fields
+// whose validate tag contains neither required, omitnil nor omitempty get `omitnil` prepended such that their
+// rules only run when the field is present
+type ValidateRequired struct {
+	Value bool
+}
+
+func (o ValidateRequired) update(cfg config) config {
+	cfg.validateRequired = o.Value
+	return cfg
+}
+
+// OmitNil if true (default false) prepends `omitnil` to the go-playground/validator `validate` tags of pointerized
+// fields whose tag contains neither required, omitnil nor omitempty, such that their rules only run when the field
+// is present. Unlike ValidateRequired, fields without a validate tag are left as is.
+type OmitNil struct {
+	Value bool
+}
+
+func (o OmitNil) update(cfg config) config {
+	cfg.omitNil = o.Value
+	return cfg
+}
+
+// FlattenEmbedded if true (default false) hoists the fields of embedded structs into the rebuilt struct instead
+// of keeping the embedded struct as an anonymous field. Fields declared directly take precedence over promoted
+// fields and conflicting promoted fields are dropped, like encoding/json does.
+type FlattenEmbedded struct {
+	Value bool
+}
+
+func (o FlattenEmbedded) update(cfg config) config {
+	cfg.flattenEmbedded = o.Value
+	return cfg
+}
+
+// SafeMapKeys if true (default false) keeps the original key type of maps whose nullified key type would contain
+// pointers, e.g. map[string]T instead of map[*string]T or map[Point]T instead of map[struct{X *int}]T. Such keys
+// compare by address, such that lookups never match and encoding/json cannot decode into the map.
+type SafeMapKeys struct {
+	Value bool
+}
+
+func (o SafeMapKeys) update(cfg config) config {
+	cfg.safeMapKeys = o.Value
+	return cfg
+}
+
+// JsonNumbers if true (default false) nullifies integer and floating point types to *json.Number instead of a
+// pointer to the numeric type, such that precision-sensitive values (e.g. large int64 IDs or monetary amounts) are
+// kept as sent until they are parsed. CopyMatching and Coalesce convert them back to the original numeric types,
+// returning an error if a number does not fit. Bytes of []byte and byte arrays are not affected.
+type JsonNumbers struct {
+	Value bool
+}
+
+func (o JsonNumbers) update(cfg config) config {
+	cfg.jsonNumbers = o.Value
+	return cfg
+}
+
+// StringMapKeys if true (default false) replaces map keys that encoding/json cannot encode or decode by string keys,
+// e.g. map[string]T instead of map[float64]T, map[*string]T or map[Point]T. Keys are converted to and from their
+// string form by CopyMatching: encoding.TextMarshaler implementations by their text, booleans and numbers with
+// strconv, fmt.Stringer implementations by their String method and other types as JSON. Keys that are a string,
+// an integer or implement encoding.TextMarshaler and encoding.TextUnmarshaler are kept.
+type StringMapKeys struct {
+	Value bool
+}
+
+func (o StringMapKeys) update(cfg config) config {
+	cfg.stringMapKeys = o.Value
+	return cfg
+}
+
+// SkipUnserializable if true (default false) leaves struct fields of kind chan, func and unsafe.Pointer (or pointers
+// to them, or slices, arrays and maps holding them) out of the rebuilt struct instead of pointerizing them, as
+// encoding/json cannot marshal them and validators cannot inspect them
+type SkipUnserializable struct {
+	Value bool
+}
+
+func (o SkipUnserializable) update(cfg config) config {
+	cfg.skipUnserializable = o.Value
+	return cfg
+}
+
+// ValueResult if true (default false) makes Nullify and TypeOf return the value form of the outermost type rather
+// than a pointer to it, e.g. `struct{ Name *string }` instead of `*struct{ Name *string }`. Nested types are not
+// affected. The value form cannot be decoded into directly, take its address first. The helpers built on Nullify
+// (e.g. Unmarshal, NewPool and ValidateBatch) are not affected and keep returning pointers.
+type ValueResult struct {
+	Value bool
+}
+
+func (o ValueResult) update(cfg config) config {
+	cfg.valueResult = o.Value
+	return cfg
+}
+
+// NullifyStructFields if true (default true) pointerizes struct fields holding a struct, e.g. `Address Address`
+// becomes `Address *struct{...}`. If false such fields keep a value struct whose own fields are nullified, such that
+// the nested struct is always present and only its leaves track presence. Fields declared as a pointer to a struct
+// remain pointers.
+type NullifyStructFields struct {
+	Value bool
+}
+
+func (o NullifyStructFields) update(cfg config) config {
+	cfg.nullifyStructFields = o.Value
+	return cfg
+}
+
+// BareContainers if true (default false) leaves slices and maps themselves un-pointerized, as they are nilable
+// already: `Tags []string` becomes `Tags []*string` rather than `*[]*string` with NullifySliceElem. A nil slice or
+// map is not set, encoding/json leaves it nil for a missing key or null but allocates it for [] and {}. Element
+// nullification is controlled separately by NullifyArrayElem, NullifySliceElem and NullifyMapElem. Pass the option
+// to the helpers as well (e.g. CopyMatching and Flatten), such that they treat nil slices and maps as not set.
+type BareContainers struct {
+	Value bool
+}
+
+func (o BareContainers) update(cfg config) config {
+	cfg.bareContainers = o.Value
+	return cfg
+}
+
+// NullifyInterfaceElem if true (default false) nullifies interface elements of arrays, slices and maps, e.g.
+// []*any instead of []any. Interfaces substituted with WithInterfaceImpl or WithTypeOverride are not affected.
+type NullifyInterfaceElem struct {
+	Value bool
+}
+
+func (o NullifyInterfaceElem) update(cfg config) config {
+	cfg.nullifyInterfaceElem = o.Value
+	return cfg
+}
+
+// Recursion determines how a recursive reference to a struct type that is being nullified is substituted
+type Recursion int
+
+const (
+	RecursionPanic    Recursion = iota // panic, as a recursive type cannot be nullified completely
+	RecursionAny                       // substitute *any, e.g. decoding a json object into a map[string]any
+	RecursionOriginal                  // substitute a pointer to the original type, e.g. Next *Node
+)
+
+func (r Recursion) String() string {
+	switch r {
+	case RecursionPanic:
+		return "RecursionPanic"
+	case RecursionAny:
+		return "RecursionAny"
+	case RecursionOriginal:
+		return "RecursionOriginal"
+	default:
+		return "Recursion(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// OnRecursion determines how recursive types such as trees and linked lists are nullified (default
+// RecursionPanic). The first occurrence of the type is nullified and references to it from within are substituted.
+type OnRecursion struct {
+	Value Recursion
+}
+
+func (o OnRecursion) update(cfg config) config {
+	cfg.recursion = o.Value
+	return cfg
+}
+
+// ByteEncoding is the textual representation of bytes that are processed as a string, see BytesAsString
+type ByteEncoding int
+
+const (
+	ByteEncodingBase64 ByteEncoding = iota // standard base64 with padding, as used by encoding/json
+	ByteEncodingHex                        // lower-case hexadecimal, decoding accepts upper-case as well
+	ByteEncodingRaw                        // the bytes as is
+)
+
+func (e ByteEncoding) String() string {
+	switch e {
+	case ByteEncodingBase64:
+		return "ByteEncodingBase64"
+	case ByteEncodingHex:
+		return "ByteEncodingHex"
+	case ByteEncodingRaw:
+		return "ByteEncodingRaw"
+	default:
+		return "ByteEncoding(" + strconv.Itoa(int(e)) + ")"
+	}
+}
+
+// EncodeBytes determines the representation of bytes in strings (default ByteEncodingBase64) when byte data is
+// converted by CopyMatching, Coalesce and friends, e.g. the *string of a []byte field with BytesAsString copied
+// back into the original type. Use it for hex-encoded API fields or decoders other than encoding/json.
+type EncodeBytes struct {
+	Value ByteEncoding
+}
+
+func (o EncodeBytes) update(cfg config) config {
+	cfg.byteEncoding = o.Value
+	return cfg
+}
+
+// Matching determines how struct fields of the source and destination are matched when copying
+type Matching int
+
+const (
+	MatchJsonName Matching = iota // equal json names, following WithTagPriority
+	MatchGoName                   // equal Go field names
+	MatchLoose                    // equal json names, else equal Go field names, else either compared case-insensitively
+)
+
+func (m Matching) String() string {
+	switch m {
+	case MatchJsonName:
+		return "MatchJsonName"
+	case MatchGoName:
+		return "MatchGoName"
+	case MatchLoose:
+		return "MatchLoose"
+	default:
+		return "Matching(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// MatchFields determines how CopyMatching, Coalesce and friends match struct fields (default MatchJsonName), e.g.
+// MatchLoose for nullified values built by external tooling or renamed with WithFieldNameFunc
+type MatchFields struct {
+	Value Matching
+}
+
+func (o MatchFields) update(cfg config) config {
+	cfg.matching = o.Value
+	return cfg
+}
+
+// StripTags removes the listed tag keys (e.g. gorm, db) from every rebuilt struct field, such that persistence
+// tags do not leak into transport-layer types
+type StripTags struct {
+	Value []string
+}
+
+func (o StripTags) update(cfg config) config {
+	cfg.stripTags = append(slices.Clip(cfg.stripTags), o.Value...)
+	return cfg
+}
+
+// RemapTag rewrites the value of the tag Key of every rebuilt struct field that has the tag, e.g. to rename json keys.
+// Value receives the complete tag value including options (e.g. `name,omitempty`).
+type RemapTag struct {
+	Key   string
+	Value func(value string) string
+}
+
+func (o RemapTag) update(cfg config) config {
+	cfg.tagRemaps = append(slices.Clip(cfg.tagRemaps), o)
+	return cfg
+}
+
+// fieldTransform appends a transformation applied to every rebuilt struct field
+type fieldTransform struct {
+	fn func(reflect.StructField) reflect.StructField
+}
+
+func (o fieldTransform) update(cfg config) config {
+	cfg.fieldTransforms = append(slices.Clip(cfg.fieldTransforms), o.fn)
+	return cfg
+}
+
+// WithFieldTransform registers a function that is called for every struct field after its type is nullified, e.g.
+// to rename fields, inject tags or change types. Returning a StructField with an empty Name skips the field.
+// Multiple transforms are applied in the order they are passed.
+func WithFieldTransform(fn func(reflect.StructField) reflect.StructField) option {
+	return fieldTransform{fn: fn}
+}
+
+// tagPriority sets the tags that name fields in the helpers, highest priority first
+type tagPriority struct {
+	keys []string
+}
+
+func (o tagPriority) update(cfg config) config {
+	cfg.tagPriority = slices.Clip(o.keys)
+	return cfg
+}
+
+// WithTagPriority sets the tags consulted, in order, by the helpers that resolve field names (e.g. CopyMatching,
+// UpdateMap, SetClause, BsonSet and NullifyToMap) when a struct carries several of them, e.g.
+// WithTagPriority("json", "yaml", "db"). The first tag that is present with a name (or "-" to skip the field) wins.
+// If none names the field, the helper falls back to its default naming. Tags must use the `name,options` format.
+func WithTagPriority(keys ...string) option {
+	return tagPriority{keys: keys}
+}
+
+// fieldNameFunc appends a function renaming every rebuilt struct field
+type fieldNameFunc struct {
+	fn func(string) string
+}
+
+func (o fieldNameFunc) update(cfg config) config {
+	cfg.fieldNameFuncs = append(slices.Clip(cfg.fieldNameFuncs), o.fn)
+	return cfg
+}
+
+// WithFieldNameFunc registers a function that renames every rebuilt struct field, e.g. to add a prefix or
+// disambiguate names. It is applied after FlattenEmbedded, embedded fields are not renamed. Returning an empty name
+// skips the field. The result must be a unique exported identifier, note that encoding/json uses it as the name of
+// fields without a json tag. Multiple functions are applied in the order they are passed.
+func WithFieldNameFunc(fn func(string) string) option {
+	return fieldNameFunc{fn: fn}
+}
+
+// fieldOptions applies options to the struct field at path and everything below it
+type fieldOptions struct {
+	path    string
+	options []option
+}
+
+func (o fieldOptions) update(cfg config) config {
+	cfg.fieldOptions = append(slices.Clip(cfg.fieldOptions), o)
+	return cfg
+}
+
+// WithFieldOptions applies options only to the struct field at the dotted path of Go field names (e.g.
+// "Address.Street") and to everything below it, overriding the options that apply to the rest of the type.
+func WithFieldOptions(path string, options ...option) option {
+	return fieldOptions{path: path, options: options}
+}
+
+// typeOverride registers the type emitted in place of another type
+type typeOverride struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+func (o typeOverride) update(cfg config) config {
+	overrides := make(map[reflect.Type]reflect.Type, len(cfg.typeOverrides)+1)
+	for from, to := range cfg.typeOverrides {
+		overrides[from] = to
+	}
+	overrides[o.from] = o.to
+	cfg.typeOverrides = overrides
+	return cfg
+}
+
+// WithTypeOverride emits the type to wherever the type from is encountered during the walk, e.g. *string for a
+// decimal type or *int for an enum. The type to is used as is: it is not nullified any further.
+func WithTypeOverride(from reflect.Type, to reflect.Type) option {
+	return typeOverride{from: from, to: to}
+}
+
+// WithLeaf keeps t as a leaf wherever it is encountered during the walk, i.e. t and *t become *t rather than being
+// decomposed into a struct of pointers, e.g. for decimal.Decimal together with NullifyMarshalJson. It is
+// WithTypeOverride(t, *t), see NumericOptions for the math/big types.
+func WithLeaf(t reflect.Type) option {
+	return typeOverride{from: t, to: reflect.PointerTo(t)}
+}
+
+// interfaceImpl registers the concrete type used in place of an interface type
+type interfaceImpl struct {
+	iface reflect.Type
+	impl  reflect.Type
+}
+
+func (o interfaceImpl) update(cfg config) config {
+	impls := make(map[reflect.Type]reflect.Type, len(cfg.interfaceImpls)+1)
+	for iface, impl := range cfg.interfaceImpls {
+		impls[iface] = impl
+	}
+	impls[o.iface] = o.impl
+	cfg.interfaceImpls = impls
+	return cfg
+}
+
+// WithInterfaceImpl substitutes the nullified form of the concrete type T wherever the interface type I is
+// encountered, such that e.g. fields declared as a domain interface can be decoded and presence-checked.
+// It panics if I is not an interface type or T does not implement I.
+func WithInterfaceImpl[I any, T any]() option {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	impl := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic("nullify: WithInterfaceImpl: " + iface.String() + " is not an interface type")
+	}
+	if !impl.Implements(iface) && !reflect.PointerTo(impl).Implements(iface) {
+		panic("nullify: WithInterfaceImpl: " + impl.String() + " does not implement " + iface.String())
+	}
+	return interfaceImpl{iface: iface, impl: impl}
+}
+
+// TypeNullifier is implemented by types that control their own nullified type. When the walk encounters a type
+// implementing TypeNullifier (with a value or pointer receiver), it uses the returned type instead of descending
+// into the type. The method is called on the zero value of the type.
+type TypeNullifier interface {
+	NullifyType() reflect.Type
+}
+
+// typeNullifier TypeNullifier type
+var typeNullifier = reflect.TypeOf((*TypeNullifier)(nil)).Elem()
+
+// sqlScanner sql.Scanner type
+var sqlScanner = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// driverValuer driver.Valuer type
+var driverValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
 // jsonMarshaler json.Marshaler type
 var jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 
 // jsonUnmarshaler json.Unmarshaler type
 var jsonUnmarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 
-// ptr recursively transforms the `reflect.Type` to a pointer kind.
+// ptr recursively transforms the `reflect.Type` to a pointer kind. Results are memoized in cfg.memo such that
+// types occurring repeatedly (e.g. the same nested struct in many fields) are only transformed once. As path-based
+// options make the result depend on the location of a type, memoization is disabled when they are used. Results
+// containing a substituted recursive reference depend on the types being visited and are not memoized either.
 func ptr(t reflect.Type, cfg config) reflect.Type {
+	if cfg.memo == nil || cfg.substitutions == nil || len(cfg.fieldOptions) > 0 {
+		return transform(t, cfg)
+	}
+
+	if val, ok := cfg.memo[t]; ok {
+		return val
+	}
+	substitutions := *cfg.substitutions
+	val := transform(t, cfg)
+	if *cfg.substitutions == substitutions {
+		cfg.memo[t] = val
+	}
+	return val
+}
+
+// transform transforms the `reflect.Type` to a pointer kind, calling ptr for nested types.
+func transform(t reflect.Type, cfg config) reflect.Type {
+	if to, ok := cfg.typeOverrides[t]; ok {
+		return to
+	}
+
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		if t.Implements(typeNullifier) {
+			return reflect.Zero(t).Interface().(TypeNullifier).NullifyType()
+		}
+		if reflect.PointerTo(t).Implements(typeNullifier) {
+			return reflect.New(t).Interface().(TypeNullifier).NullifyType()
+		}
+	}
+
+	if cfg.protobuf && (isProtoEnum(t) || isWellKnownProto(t)) {
+		return reflect.TypeOf((*any)(nil))
+	}
+
+	if impl, ok := cfg.interfaceImpls[t]; ok {
+		return ptr(impl, cfg)
+	}
+
+	if cfg.keepNamedBytes && isNamedBytes(t) {
+		return reflect.PointerTo(t)
+	}
+
 	if !cfg.nullifyMarshalJson && t.Implements(jsonMarshaler) {
 		return reflect.PointerTo(t)
 	}
@@ -162,27 +860,36 @@ func ptr(t reflect.Type, cfg config) reflect.Type {
 		return reflect.PointerTo(t)
 	}
 
+	if !cfg.nullifySqlScanner && t.Kind() != reflect.Pointer && (reflect.PointerTo(t).Implements(sqlScanner) || t.Implements(driverValuer)) {
+		return reflect.PointerTo(t)
+	}
+
 	switch t.Kind() {
 	case reflect.Struct:
-		structFields := make([]reflect.StructField, t.NumField())
-		for i := range structFields {
-			structFields[i] = t.Field(i)
-			structFields[i].Type = ptr(structFields[i].Type, cfg)
+		if slices.Contains(cfg.visiting, t) {
+			return recursive(t, cfg)
 		}
-		return reflect.PointerTo(reflect.StructOf(structFields))
+		cfg.visiting = append(slices.Clip(cfg.visiting), t)
+		return reflect.PointerTo(reflect.StructOf(structFields(t, cfg)))
 	case reflect.Array:
-		if cfg.bytesAsString && (t.Elem().Kind() == reflect.Uint8 || (t.Elem().Kind() == reflect.Pointer && t.Elem().Elem().Kind() == reflect.Uint8)) {
-			elemType := reflect.PointerTo(reflect.TypeOf(""))
-			return elemType
+		if isByteArray(t) && (t.Name() != "" || cfg.bytesAsString) {
+			// e.g. uuid.UUID: encoded as text or as an array of numbers rather than as a base64 string
+			return reflect.PointerTo(t)
 		}
 
 		elemType := ptr(t.Elem(), cfg)
+		if cfg.jsonNumbers && t.Elem().Kind() == reflect.Uint8 {
+			elemType = reflect.PointerTo(t.Elem()) // bytes rather than numbers
+		}
 		if cfg.nullifyArrayElem && elemType.Kind() != reflect.Pointer {
 			elemType = reflect.PointerTo(elemType)
 		}
 		if !cfg.nullifyArrayElem && elemType.Kind() == reflect.Pointer {
 			elemType = elemType.Elem()
 		}
+		if !cfg.nullifyInterfaceElem && isInterfaceElem(t.Elem(), elemType) {
+			elemType = t.Elem()
+		}
 
 		return reflect.PointerTo(reflect.ArrayOf(t.Len(), elemType))
 	case reflect.Slice:
@@ -195,13 +902,22 @@ func ptr(t reflect.Type, cfg config) reflect.Type {
 		}
 
 		elemType := ptr(t.Elem(), cfg)
+		if cfg.jsonNumbers && t.Elem().Kind() == reflect.Uint8 {
+			elemType = reflect.PointerTo(t.Elem()) // bytes rather than numbers
+		}
 		if cfg.nullifySliceElem && elemType.Kind() != reflect.Pointer {
 			elemType = reflect.PointerTo(elemType)
 		}
 		if !cfg.nullifySliceElem && elemType.Kind() == reflect.Pointer {
 			elemType = elemType.Elem()
 		}
+		if !cfg.nullifyInterfaceElem && isInterfaceElem(t.Elem(), elemType) {
+			elemType = t.Elem()
+		}
 
+		if cfg.bareContainers {
+			return reflect.SliceOf(elemType)
+		}
 		return reflect.PointerTo(reflect.SliceOf(elemType))
 	case reflect.Map:
 		elemType := ptr(t.Elem(), cfg)
@@ -211,6 +927,9 @@ func ptr(t reflect.Type, cfg config) reflect.Type {
 		if !cfg.nullifyMapElem && elemType.Kind() == reflect.Pointer {
 			elemType = elemType.Elem()
 		}
+		if !cfg.nullifyInterfaceElem && isInterfaceElem(t.Elem(), elemType) {
+			elemType = t.Elem()
+		}
 
 		keyType := ptr(t.Key(), cfg)
 		if cfg.nullifyMapKey && keyType.Kind() != reflect.Pointer {
@@ -219,10 +938,22 @@ func ptr(t reflect.Type, cfg config) reflect.Type {
 		if !cfg.nullifyMapKey && keyType.Kind() == reflect.Pointer {
 			keyType = keyType.Elem()
 		}
+		if cfg.safeMapKeys && hasPointers(keyType) {
+			keyType = t.Key()
+		}
+		if cfg.stringMapKeys && !isJsonKey(keyType) {
+			keyType = reflect.TypeOf("")
+		}
 
+		if cfg.bareContainers {
+			return reflect.MapOf(keyType, elemType)
+		}
 		return reflect.PointerTo(reflect.MapOf(keyType, elemType))
 	// primitive types, just return the pointer value
 	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		if cfg.jsonNumbers && isNumber(t.Kind()) {
+			return reflect.PointerTo(jsonNumberType)
+		}
 		return reflect.PointerTo(t)
 	// recursively follow pointer and return the non-pointer version, then call ptr on that to resolve to a 1-depth pointer
 	case reflect.Pointer:
@@ -234,3 +965,237 @@ func ptr(t reflect.Type, cfg config) reflect.Type {
 		return reflect.PointerTo(t)
 	}
 }
+
+// recursive returns the substitute of a recursive reference to the struct type t
+func recursive(t reflect.Type, cfg config) reflect.Type {
+	if cfg.substitutions != nil {
+		*cfg.substitutions++
+	}
+	switch cfg.recursion {
+	case RecursionAny:
+		return reflect.TypeOf((*any)(nil))
+	case RecursionOriginal:
+		return reflect.PointerTo(t)
+	default:
+		panic("nullify: " + t.String() + " is recursive, use OnRecursion to substitute recursive references")
+	}
+}
+
+// isInterfaceElem returns true if elem is an interface type that was pointerized into elemType as is
+func isInterfaceElem(elem reflect.Type, elemType reflect.Type) bool {
+	return elem.Kind() == reflect.Interface && (elemType == elem || elemType == reflect.PointerTo(elem))
+}
+
+// hasPointers returns true if t is or contains (through struct fields or array elements) a pointer
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return true
+	case reflect.Array:
+		return hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isJsonKey returns true if encoding/json can encode and decode map keys of type t
+func isJsonKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Pointer:
+		return false
+	default:
+		return t.Implements(textMarshaler) && reflect.PointerTo(t).Implements(textUnmarshaler)
+	}
+}
+
+// isByteArray returns true for arrays of (pointers to) bytes, e.g. [16]byte or uuid.UUID
+func isByteArray(t reflect.Type) bool {
+	if t.Kind() != reflect.Array {
+		return false
+	}
+	elem := t.Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Uint8
+}
+
+// isNamedBytes returns true for named types with []byte as underlying type
+func isNamedBytes(t reflect.Type) bool {
+	return t.Name() != "" && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// structFields returns the nullified fields of the struct type t. Embedded structs remain anonymous such that
+// encoding/json keeps flattening their fields, unless flattenEmbedded hoists them into the struct explicitly.
+func structFields(t reflect.Type, cfg config) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if field, ok := nullifyField(t.Field(i), cfg); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	if cfg.flattenEmbedded {
+		fields = flattenEmbedded(fields)
+	}
+
+	if len(cfg.fieldNameFuncs) > 0 {
+		fields = renameFields(fields, cfg)
+	}
+
+	return fields
+}
+
+// renameFields applies the configured field name functions to the non-embedded fields, dropping fields renamed
+// to an empty name
+func renameFields(fields []reflect.StructField, cfg config) []reflect.StructField {
+	renamed := fields[:0]
+	for _, field := range fields {
+		for _, fn := range cfg.fieldNameFuncs {
+			if !field.Anonymous && field.Name != "" {
+				field.Name = fn(field.Name)
+			}
+		}
+		if field.Name != "" {
+			renamed = append(renamed, field)
+		}
+	}
+	return renamed
+}
+
+// flattenEmbedded replaces embedded struct fields by their fields. Following the promotion rules of Go, fields
+// declared directly win over promoted fields and promoted fields with conflicting names are dropped.
+func flattenEmbedded(fields []reflect.StructField) []reflect.StructField {
+	declared := make(map[string]bool, len(fields))
+	promoted := make(map[string]int)
+	for _, field := range fields {
+		if isFlattenable(field) {
+			for i := 0; i < field.Type.Elem().NumField(); i++ {
+				promoted[field.Type.Elem().Field(i).Name]++
+			}
+		} else {
+			declared[field.Name] = true
+		}
+	}
+
+	flattened := make([]reflect.StructField, 0, len(fields))
+	for _, field := range fields {
+		if !isFlattenable(field) {
+			flattened = append(flattened, field)
+			continue
+		}
+
+		for i := 0; i < field.Type.Elem().NumField(); i++ {
+			hoisted := field.Type.Elem().Field(i)
+			if declared[hoisted.Name] || promoted[hoisted.Name] > 1 {
+				continue
+			}
+			hoisted.Index = nil
+			hoisted.Offset = 0
+			flattened = append(flattened, hoisted)
+		}
+	}
+	return flattened
+}
+
+// isFlattenable returns true if field is an embedded struct that encoding/json would flatten and is not a leaf
+// type (e.g. *time.Time)
+func isFlattenable(field reflect.StructField) bool {
+	if !field.Anonymous || field.Type.Kind() != reflect.Pointer || field.Type.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	if field.Type.Elem().Name() != "" {
+		return false
+	}
+	name, ok := jsonName(field)
+	return ok && name == field.Name
+}
+
+// nullifyField returns the nullified version of a struct field including its rewritten tags, false if the
+// field is to be left out of the rebuilt struct
+func nullifyField(field reflect.StructField, cfg config) (reflect.StructField, bool) {
+	cfg = fieldConfig(field, cfg)
+
+	if cfg.protobuf && !field.IsExported() {
+		return field, false // internal state of generated messages
+	}
+
+	if cfg.skipUnserializable && isUnserializable(field.Type) {
+		return field, false
+	}
+
+	original := field.Type
+	switch {
+	case field.Tag.Get("nullify") == "-", cfg.protobuf && field.Tag.Get("protobuf_oneof") != "":
+		// leave the type untouched
+	case field.Tag.Get("nullify") == "leaf":
+		field.Type = leaf(field.Type)
+	default:
+		field.Type = ptr(field.Type, cfg)
+		if !cfg.nullifyStructFields && original.Kind() == reflect.Struct && isNullifiedStruct(field.Type.Elem()) {
+			field.Type = field.Type.Elem()
+		}
+	}
+	field.Tag = rewriteTag(field, original, cfg)
+	if field = transformField(field, cfg); field.Name == "" {
+		return field, false
+	}
+	return field, true
+}
+
+// fieldConfig returns cfg at the path of field, applying the options registered for that path with WithFieldOptions
+func fieldConfig(field reflect.StructField, cfg config) config {
+	cfg.path = joinPath(cfg.path, field.Name)
+	for _, override := range cfg.fieldOptions {
+		if override.path == cfg.path {
+			for _, opt := range override.options {
+				cfg = opt.update(cfg)
+			}
+		}
+	}
+	return cfg
+}
+
+// isUnserializable returns true if t is, or points to, a chan, func or unsafe.Pointer, or a slice, array or map
+// holding them
+func isUnserializable(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	case reflect.Slice, reflect.Array:
+		return isUnserializable(t.Elem())
+	case reflect.Map:
+		return isUnserializable(t.Key()) || isUnserializable(t.Elem())
+	default:
+		return false
+	}
+}
+
+// leaf returns the single pointer version of t without decomposing it
+func leaf(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return reflect.PointerTo(t)
+}
+
+// transformField applies the configured field transforms in order, stopping when a transform skips the field
+func transformField(field reflect.StructField, cfg config) reflect.StructField {
+	for _, fn := range cfg.fieldTransforms {
+		if field = fn(field); field.Name == "" {
+			return field
+		}
+	}
+	return field
+}
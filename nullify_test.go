@@ -1,8 +1,15 @@
 package nullify
 
 import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	"math/big"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -171,3 +178,1326 @@ func TestNullify_Default(t *testing.T) {
 		})
 	}
 }
+
+func TestNullify_WithFieldTransform(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+
+	rename := func(field reflect.StructField) reflect.StructField {
+		if field.Name == "Name" {
+			field.Name = "FullName"
+			field.Tag = `json:"full_name"`
+		}
+		return field
+	}
+	skip := func(field reflect.StructField) reflect.StructField {
+		if field.Name == "Password" {
+			return reflect.StructField{}
+		}
+		return field
+	}
+
+	// Act
+	p := Nullify(Person{}, WithFieldTransform(rename), WithFieldTransform(skip))
+
+	// Assert
+	assert.Equal(t, 1, reflect.TypeOf(p).Elem().NumField())
+	assert.Equal(t, "FullName", reflect.TypeOf(p).Elem().Field(0).Name)
+	assert.Equal(t, reflect.StructTag(`json:"full_name"`), reflect.TypeOf(p).Elem().Field(0).Tag)
+	assert.Equal(t, reflect.Pointer, reflect.TypeOf(p).Elem().Field(0).Type.Kind())
+}
+
+func TestNullify_WithFieldNameFunc(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string
+	}
+	type Person struct {
+		Base
+		Name     string `json:"name"`
+		Internal string
+	}
+
+	// Act
+	p := Nullify(Person{},
+		WithFieldNameFunc(func(name string) string {
+			if name == "Internal" {
+				return ""
+			}
+			return name
+		}),
+		WithFieldNameFunc(func(name string) string { return "X" + name }),
+	)
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, 2, typ.NumField())
+	assert.Equal(t, "Base", typ.Field(0).Name)
+	assert.Equal(t, "XID", typ.Field(0).Type.Elem().Field(0).Name)
+	assert.Equal(t, "XName", typ.Field(1).Name)
+	assert.Equal(t, reflect.StructTag(`json:"name"`), typ.Field(1).Tag)
+}
+
+func TestNullify_WithFieldNameFunc_FlattenEmbedded(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string
+	}
+	type Person struct {
+		Base
+		Name string
+	}
+
+	// Act
+	p := Nullify(Person{}, FlattenEmbedded{Value: true}, WithFieldNameFunc(strings.ToUpper))
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, "ID", typ.Field(0).Name)
+	assert.Equal(t, "NAME", typ.Field(1).Name)
+}
+
+func TestNullify_OmitEmpty(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string `json:"name"`
+		Age      int    `json:"age,omitempty"`
+		Secret   string `json:"-"`
+		Untagged string
+	}
+
+	// Act
+	p := Nullify(Person{}, OmitEmpty{Value: true})
+
+	// Assert
+	assert.Equal(t, reflect.StructTag(`json:"name,omitempty"`), reflect.TypeOf(p).Elem().Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(`json:"age,omitempty"`), reflect.TypeOf(p).Elem().Field(1).Tag)
+	assert.Equal(t, reflect.StructTag(`json:"-"`), reflect.TypeOf(p).Elem().Field(2).Tag)
+	assert.Equal(t, reflect.StructTag(`json:",omitempty"`), reflect.TypeOf(p).Elem().Field(3).Tag)
+
+	b, err := json.Marshal(p)
+	assert.Nil(t, err)
+	assert.Equal(t, `{}`, string(b))
+}
+
+func TestNullify_OmitZero(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string `json:"name"`
+		Nickname string `json:"nickname,omitempty"`
+		Secret   string `json:"-"`
+	}
+
+	// Act
+	p := Nullify(Person{}, JsonV2Options...)
+
+	// Assert
+	assert.Equal(t, reflect.StructTag(`json:"name,omitzero"`), reflect.TypeOf(p).Elem().Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(`json:"nickname,omitempty,omitzero"`), reflect.TypeOf(p).Elem().Field(1).Tag)
+	assert.Equal(t, reflect.StructTag(`json:"-"`), reflect.TypeOf(p).Elem().Field(2).Tag)
+}
+
+func TestNullify_StripTags(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name" gorm:"column:name" db:"name"`
+	}
+
+	// Act
+	p := Nullify(Person{}, StripTags{Value: []string{"gorm", "db"}})
+
+	// Assert
+	assert.Equal(t, reflect.StructTag(`json:"name"`), reflect.TypeOf(p).Elem().Field(0).Tag)
+}
+
+func TestNullify_RemapTag(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name,omitempty" db:"name"`
+		Age  int
+	}
+
+	// Act
+	p := Nullify(Person{}, RemapTag{Key: "json", Value: func(value string) string {
+		return "person_" + value
+	}})
+
+	// Assert
+	assert.Equal(t, reflect.StructTag(`json:"person_name,omitempty" db:"name"`), reflect.TypeOf(p).Elem().Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(``), reflect.TypeOf(p).Elem().Field(1).Tag)
+}
+
+func TestNullify_Tag(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		ID      string   `nullify:"-"`
+		Home    Address  `nullify:"leaf"`
+		Work    *Address `nullify:"leaf"`
+		Address Address
+	}
+
+	// Act
+	p := Nullify(Person{})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(""), reflect.TypeOf(p).Elem().Field(0).Type)
+	assert.Equal(t, reflect.TypeOf(&Address{}), reflect.TypeOf(p).Elem().Field(1).Type)
+	assert.Equal(t, reflect.TypeOf(&Address{}), reflect.TypeOf(p).Elem().Field(2).Type)
+	assert.NotEqual(t, reflect.TypeOf(&Address{}), reflect.TypeOf(p).Elem().Field(3).Type)
+	assert.Equal(t, reflect.Pointer, reflect.TypeOf(p).Elem().Field(3).Type.Elem().Field(0).Type.Kind())
+}
+
+func TestNullify_WithFieldOptions(t *testing.T) {
+	// Arrange
+	type Metadata struct {
+		Labels []string
+	}
+	type Message struct {
+		Payload  []byte
+		Raw      []byte
+		Metadata Metadata
+	}
+
+	// Act
+	p := Nullify(Message{},
+		WithFieldOptions("Payload", BytesAsString{Value: true}),
+		WithFieldOptions("Metadata.Labels", NullifySliceElem{Value: false}),
+	)
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), reflect.TypeOf(p).Elem().Field(0).Type)
+	assert.Equal(t, reflect.TypeOf((*[]*uint8)(nil)), reflect.TypeOf(p).Elem().Field(1).Type)
+	assert.Equal(t, reflect.TypeOf((*[]string)(nil)), reflect.TypeOf(p).Elem().Field(2).Type.Elem().Field(0).Type)
+}
+
+func TestNullify_Embedded(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name string `json:"name"`
+	}
+
+	// Act
+	p := Nullify(Person{}, OmitEmpty{Value: true})
+	err := json.Unmarshal([]byte(`{"id": "1", "name": "alice"}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, reflect.TypeOf(p).Elem().Field(0).Anonymous)
+	assert.Equal(t, "1", *reflect.ValueOf(p).Elem().Field(0).Elem().Field(0).Interface().(*string))
+
+	b, err := json.Marshal(p)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": "1", "name": "alice"}`, string(b))
+}
+
+func TestNullify_FlattenEmbedded(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		CreatedAt time.Time `json:"created_at"`
+	}
+	type Base struct {
+		Audit
+		ID   string `json:"id"`
+		Name string `json:"base_name"`
+	}
+	type Other struct {
+		ID string `json:"other_id"`
+	}
+	type Named struct {
+		Value string `json:"value"`
+	}
+	type Person struct {
+		Base
+		Other
+		Named `json:"named"`
+		Name  string `json:"name"`
+	}
+
+	// Act
+	p := Nullify(Person{}, FlattenEmbedded{Value: true})
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	names := make([]string, typ.NumField())
+	for i := range names {
+		names[i] = typ.Field(i).Name
+		assert.Equal(t, reflect.Pointer, typ.Field(i).Type.Kind())
+	}
+	assert.Equal(t, []string{"CreatedAt", "Named", "Name"}, names)
+	assert.Equal(t, reflect.TypeOf(&time.Time{}), typ.Field(0).Type)
+	assert.True(t, typ.Field(1).Anonymous)
+}
+
+type shape interface {
+	Area() float64
+}
+
+type square struct {
+	Side float64 `json:"side"`
+}
+
+func (s square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func TestNullify_WithInterfaceImpl(t *testing.T) {
+	// Arrange
+	type Drawing struct {
+		Shape  shape   `json:"shape"`
+		Shapes []shape `json:"shapes"`
+		Any    any     `json:"any"`
+	}
+
+	// Act
+	p := Nullify(Drawing{}, WithInterfaceImpl[shape, square]())
+	err := json.Unmarshal([]byte(`{"shape": {"side": 2}, "shapes": [{}]}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, reflect.Struct, typ.Field(0).Type.Elem().Kind())
+	assert.Equal(t, reflect.TypeOf((*float64)(nil)), typ.Field(0).Type.Elem().Field(0).Type)
+	assert.Equal(t, reflect.Struct, typ.Field(1).Type.Elem().Elem().Elem().Kind())
+	assert.Equal(t, reflect.TypeOf((*any)(nil)), typ.Field(2).Type)
+	assert.Equal(t, float64(2), reflect.ValueOf(p).Elem().Field(0).Elem().Field(0).Elem().Float())
+}
+
+func TestWithInterfaceImpl_Panics(t *testing.T) {
+	assert.PanicsWithValue(t, "nullify: WithInterfaceImpl: nullify.square is not an interface type", func() {
+		WithInterfaceImpl[square, square]()
+	})
+	assert.PanicsWithValue(t, "nullify: WithInterfaceImpl: string does not implement nullify.shape", func() {
+		WithInterfaceImpl[shape, string]()
+	})
+}
+
+func TestNullify_KeepNamedBytes(t *testing.T) {
+	// Arrange
+	type Hash []byte
+	type Document struct {
+		Raw  json.RawMessage
+		Hash Hash
+		Data []byte
+	}
+
+	tests := map[string]struct {
+		Options []option
+		Raw     reflect.Type
+		Hash    reflect.Type
+		Data    reflect.Type
+	}{
+		"BytesAsString": {
+			Options: []option{BytesAsString{Value: true}, NullifyMarshalJson{Value: true}, NullifyUnmarshalJson{Value: true}},
+			Raw:     reflect.TypeOf((*string)(nil)),
+			Hash:    reflect.TypeOf((*string)(nil)),
+			Data:    reflect.TypeOf((*string)(nil)),
+		},
+		"KeepNamedBytes": {
+			Options: []option{BytesAsString{Value: true}, KeepNamedBytes{Value: true}, NullifyMarshalJson{Value: true}, NullifyUnmarshalJson{Value: true}},
+			Raw:     reflect.TypeOf((*json.RawMessage)(nil)),
+			Hash:    reflect.TypeOf((*Hash)(nil)),
+			Data:    reflect.TypeOf((*string)(nil)),
+		},
+		"KeepNamedBytes without BytesAsString": {
+			Options: []option{KeepNamedBytes{Value: true}},
+			Raw:     reflect.TypeOf((*json.RawMessage)(nil)),
+			Hash:    reflect.TypeOf((*Hash)(nil)),
+			Data:    reflect.TypeOf((*[]*uint8)(nil)),
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Document{}, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Raw, reflect.TypeOf(p).Elem().Field(0).Type)
+			assert.Equal(t, testData.Hash, reflect.TypeOf(p).Elem().Field(1).Type)
+			assert.Equal(t, testData.Data, reflect.TypeOf(p).Elem().Field(2).Type)
+		})
+	}
+}
+
+// byteArrayUUID mimics uuid.UUID, a named byte array encoded as text
+type byteArrayUUID [4]byte
+
+func (u byteArrayUUID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(u[:])), nil
+}
+
+func (u *byteArrayUUID) UnmarshalText(text []byte) error {
+	_, err := hex.Decode(u[:], text)
+	return err
+}
+
+func TestNullify_ByteArrays(t *testing.T) {
+	// Arrange
+	type Document struct {
+		ID       byteArrayUUID `json:"id"`
+		Checksum [4]byte       `json:"checksum"`
+	}
+
+	tests := map[string]struct {
+		Options  []option
+		ID       reflect.Type
+		Checksum reflect.Type
+	}{
+		"JsonOptions": {
+			Options:  JsonOptions,
+			ID:       reflect.TypeOf((*byteArrayUUID)(nil)),
+			Checksum: reflect.TypeOf((*[4]byte)(nil)),
+		},
+		"default": {
+			ID:       reflect.TypeOf((*byteArrayUUID)(nil)),
+			Checksum: reflect.TypeOf((*[4]*uint8)(nil)),
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Document{}, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.ID, reflect.TypeOf(p).Elem().Field(0).Type)
+			assert.Equal(t, testData.Checksum, reflect.TypeOf(p).Elem().Field(1).Type)
+		})
+	}
+}
+
+func TestNullify_ByteArrays_Unmarshal(t *testing.T) {
+	// Arrange
+	type Document struct {
+		ID       byteArrayUUID `json:"id"`
+		Checksum [4]byte       `json:"checksum"`
+	}
+	var document Document
+
+	// Act
+	_, err := Unmarshal([]byte(`{"id": "01020304", "checksum": [5, 6, 7, 8]}`), &document, JsonOptions...)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Document{ID: byteArrayUUID{1, 2, 3, 4}, Checksum: [4]byte{5, 6, 7, 8}}, document)
+}
+
+func TestNullify_WithTypeOverride(t *testing.T) {
+	// Arrange
+	type Status int
+	type Order struct {
+		Status   Status    `json:"status"`
+		Statuses []Status  `json:"statuses"`
+		Placed   time.Time `json:"placed"`
+	}
+
+	// Act
+	p := Nullify(Order{},
+		WithTypeOverride(reflect.TypeOf(Status(0)), reflect.TypeOf((*int)(nil))),
+		WithTypeOverride(reflect.TypeOf(time.Time{}), reflect.TypeOf((*string)(nil))),
+	)
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf((*int)(nil)), reflect.TypeOf(p).Elem().Field(0).Type)
+	assert.Equal(t, reflect.TypeOf((*[]*int)(nil)), reflect.TypeOf(p).Elem().Field(1).Type)
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), reflect.TypeOf(p).Elem().Field(2).Type)
+}
+
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+func (money) NullifyType() reflect.Type {
+	return reflect.TypeOf((*string)(nil))
+}
+
+type code struct {
+	Value string
+}
+
+func (*code) NullifyType() reflect.Type {
+	return reflect.TypeOf((*code)(nil))
+}
+
+func TestNullify_TypeNullifier(t *testing.T) {
+	// Arrange
+	type Product struct {
+		Price money
+		Codes []code
+		Code  *code
+	}
+
+	// Act
+	p := Nullify(Product{})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), reflect.TypeOf(p).Elem().Field(0).Type)
+	assert.Equal(t, reflect.TypeOf((*[]*code)(nil)), reflect.TypeOf(p).Elem().Field(1).Type)
+	assert.Equal(t, reflect.TypeOf((*code)(nil)), reflect.TypeOf(p).Elem().Field(2).Type)
+}
+
+func TestNullify_ValidateRequired(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string
+		Nickname *string
+		Email    string `validate:"email"`
+		ID       string `validate:"required,uuid"`
+		Website  string `validate:"omitempty,url"`
+		Internal string `nullify:"-"`
+		Empty    string `validate:""`
+	}
+
+	// Act
+	p := Nullify(Person{}, ValidateRequired{Value: true})
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, reflect.StructTag(`validate:"required"`), typ.Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(``), typ.Field(1).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"omitnil,email"`), typ.Field(2).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"required,uuid"`), typ.Field(3).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"omitempty,url"`), typ.Field(4).Tag)
+	assert.Equal(t, reflect.StructTag(`nullify:"-"`), typ.Field(5).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:""`), typ.Field(6).Tag)
+}
+
+func TestNullify_ValidatorOptions(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name    string
+		Email   string   `validate:"email"`
+		ID      string   `validate:"required,uuid"`
+		Website string   `validate:"omitnil,url"`
+		Tags    []string `validate:"dive,required"`
+		Empty   string   `validate:""`
+	}
+
+	// Act
+	p := Nullify(Person{}, ValidatorOptions...)
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, reflect.StructTag(``), typ.Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"omitnil,email"`), typ.Field(1).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"required,uuid"`), typ.Field(2).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"omitnil,url"`), typ.Field(3).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:"omitnil,dive,required"`), typ.Field(4).Tag)
+	assert.Equal(t, reflect.StructTag(`validate:""`), typ.Field(5).Tag)
+	assert.Equal(t, len(JsonOptions)+1, len(ValidatorOptions))
+}
+
+func TestNullify_SqlOptions(t *testing.T) {
+	// Arrange
+	type User struct {
+		ID       int64             `db:"id"`
+		Name     sql.NullString    `db:"name"`
+		Tags     []string          `db:"tags"`
+		Avatar   []byte            `db:"avatar"`
+		Labels   map[string]string `db:"labels"`
+		Created  time.Time         `db:"created"`
+		Internal string            `db:"-"`
+	}
+
+	// Act
+	p := Nullify(User{}, SqlOptions...)
+
+	// Assert
+	typ := reflect.TypeOf(p).Elem()
+	assert.Equal(t, reflect.TypeOf((*int64)(nil)), typ.Field(0).Type)
+	assert.Equal(t, reflect.StructTag(`db:"id"`), typ.Field(0).Tag)
+	assert.Equal(t, reflect.TypeOf((*sql.NullString)(nil)), typ.Field(1).Type)
+	assert.Equal(t, reflect.TypeOf((*[]string)(nil)), typ.Field(2).Type)
+	assert.Equal(t, reflect.TypeOf((*[]uint8)(nil)), typ.Field(3).Type)
+	assert.Equal(t, reflect.TypeOf((*map[string]string)(nil)), typ.Field(4).Type)
+	assert.Equal(t, reflect.TypeOf((*time.Time)(nil)), typ.Field(5).Type)
+}
+
+func TestNullify_NullifySqlScanner(t *testing.T) {
+	// Act
+	p := Nullify(sql.NullString{})
+
+	// Assert
+	assert.Equal(t, reflect.Struct, reflect.TypeOf(p).Elem().Kind())
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), reflect.TypeOf(p).Elem().Field(0).Type)
+}
+
+func TestNullify_SafeMapKeys(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	tests := map[string]struct {
+		Input    any
+		Options  []option
+		Expected reflect.Type
+	}{
+		"string key": {
+			Input:    map[string]int{},
+			Options:  []option{SafeMapKeys{Value: true}},
+			Expected: reflect.TypeOf(map[string]*int{}),
+		},
+		"struct key": {
+			Input:    map[point]int{},
+			Options:  []option{SafeMapKeys{Value: true}, NullifyMapKey{Value: false}},
+			Expected: reflect.TypeOf(map[point]*int{}),
+		},
+		"unsafe": {
+			Input:    map[string]int{},
+			Expected: reflect.TypeOf(map[*string]*int{}),
+		},
+		"json options": {
+			Input:    map[point]int{},
+			Options:  JsonOptions,
+			Expected: reflect.TypeOf(map[point]int{}),
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(testData.Input, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Expected, reflect.TypeOf(p).Elem())
+		})
+	}
+}
+
+func TestNullify_SafeMapKeys_Unmarshal(t *testing.T) {
+	// Arrange
+	p := Nullify(map[string]int{}, SafeMapKeys{Value: true})
+
+	// Act
+	err := json.Unmarshal([]byte(`{"a": 1}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *(*p.(*map[string]*int))["a"])
+}
+
+func TestNullify_StringMapKeys(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+
+	tests := map[string]struct {
+		Input    any
+		Options  []option
+		Expected reflect.Type
+	}{
+		"pointer key": {
+			Input:    map[string]int{},
+			Options:  []option{StringMapKeys{Value: true}},
+			Expected: reflect.TypeOf(map[string]*int{}),
+		},
+		"struct key": {
+			Input:    map[Point]int{},
+			Options:  append(slices.Clip(JsonOptions), StringMapKeys{Value: true}),
+			Expected: reflect.TypeOf(map[string]int{}),
+		},
+		"float key": {
+			Input:    map[float64]int{},
+			Options:  append(slices.Clip(JsonOptions), StringMapKeys{Value: true}),
+			Expected: reflect.TypeOf(map[string]int{}),
+		},
+		"integer key": {
+			Input:    map[int]int{},
+			Options:  append(slices.Clip(JsonOptions), StringMapKeys{Value: true}),
+			Expected: reflect.TypeOf(map[int]int{}),
+		},
+		"text key": {
+			Input:    map[time.Time]int{},
+			Options:  append(slices.Clip(JsonOptions), StringMapKeys{Value: true}),
+			Expected: reflect.TypeOf(map[time.Time]int{}),
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(testData.Input, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Expected, reflect.TypeOf(p).Elem())
+		})
+	}
+}
+
+func TestNullify_StringMapKeys_RoundTrip(t *testing.T) {
+	// Arrange
+	type Point struct {
+		X, Y int
+	}
+
+	type Chart struct {
+		Points  map[Point]string `json:"points"`
+		Weights map[float64]bool `json:"weights"`
+	}
+	options := append(slices.Clip(JsonOptions), StringMapKeys{Value: true})
+	var chart Chart
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"points": {"{\"X\":1,\"Y\":2}": "a"}, "weights": {"0.5": true}}`), &chart,
+		options...)
+	p := Nullify(Chart{}, options...)
+	errCopy := CopyMatching(&chart, p, options...)
+	data, errMarshal := json.Marshal(p)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, presence)
+	assert.Equal(t, Chart{
+		Points:  map[Point]string{{X: 1, Y: 2}: "a"},
+		Weights: map[float64]bool{0.5: true},
+	}, chart)
+	assert.NoError(t, errCopy)
+	assert.NoError(t, errMarshal)
+	assert.JSONEq(t, `{"points": {"{\"X\":1,\"Y\":2}": "a"}, "weights": {"0.5": true}}`, string(data))
+}
+
+func TestNullify_StringMapKeys_InvalidKey(t *testing.T) {
+	// Arrange
+	type Chart struct {
+		Weights map[float64]bool `json:"weights"`
+	}
+	var chart Chart
+
+	// Act
+	_, err := Unmarshal([]byte(`{"weights": {"heavy": true}}`), &chart, StringMapKeys{Value: true})
+
+	// Assert
+	assert.EqualError(t, err, `nullify: Weights: key "heavy": strconv.ParseFloat: parsing "heavy": invalid syntax`)
+}
+
+func TestNullify_StringOption(t *testing.T) {
+	// Arrange
+	type Account struct {
+		ID      int64          `json:"id,string"`
+		Balance *float64       `json:"balance,string"`
+		Data    []byte         `json:"data,string"`
+		Created time.Duration  `json:"created,string"`
+		Parent  **int          `json:"parent,string"`
+		Meta    map[string]int `json:"meta,string"`
+	}
+	type seconds struct {
+		Seconds int `json:"seconds"`
+	}
+	override := WithTypeOverride(reflect.TypeOf(time.Duration(0)), reflect.TypeOf(&seconds{}))
+	options := append(slices.Clip(JsonOptions), override)
+
+	// Act
+	typ := TypeOf(Account{}, options...).Elem()
+
+	// Assert
+	tags := map[string]reflect.StructTag{}
+	for i := 0; i < typ.NumField(); i++ {
+		tags[typ.Field(i).Name] = typ.Field(i).Tag
+	}
+	assert.Equal(t, map[string]reflect.StructTag{
+		"ID":      `json:"id,string"`,
+		"Balance": `json:"balance,string"`,
+		"Data":    `json:"data"`,
+		"Created": `json:"created"`,
+		"Parent":  `json:"parent"`,
+		"Meta":    `json:"meta,string"`,
+	}, tags)
+}
+
+func TestNullify_StringOptionRoundTrip(t *testing.T) {
+	// Arrange
+	type Account struct {
+		ID   int64  `json:"id,string"`
+		Data []byte `json:"data,string"`
+	}
+	var account Account
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"id": "42", "data": "aGk="}`), &account, JsonOptions...)
+	data, errMarshal := json.Marshal(presence)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Account{ID: 42, Data: []byte("hi")}, account)
+	assert.NoError(t, errMarshal)
+	assert.JSONEq(t, `{"id": "42", "data": "aGk="}`, string(data))
+}
+
+func TestNullify_JsonNumbers(t *testing.T) {
+	// Arrange
+	type Payment struct {
+		ID      int64         `json:"id"`
+		Amount  float32       `json:"amount"`
+		Timeout time.Duration `json:"timeout"`
+		Scores  []uint        `json:"scores"`
+		Data    []byte        `json:"data"`
+		Hash    [2]byte       `json:"hash"`
+		Note    string        `json:"note"`
+	}
+
+	// Act
+	p := Nullify(Payment{}, JsonNumbers{Value: true})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(&struct {
+		ID      *json.Number    `json:"id"`
+		Amount  *json.Number    `json:"amount"`
+		Timeout *json.Number    `json:"timeout"`
+		Scores  *[]*json.Number `json:"scores"`
+		Data    *[]*uint8       `json:"data"`
+		Hash    *[2]*uint8      `json:"hash"`
+		Note    *string         `json:"note"`
+	}{}), reflect.TypeOf(p))
+}
+
+func TestNullify_NumericOptions(t *testing.T) {
+	// Arrange
+	type Account struct {
+		Balance big.Int    `json:"balance"`
+		Rate    *big.Float `json:"rate"`
+		Share   big.Rat    `json:"share"`
+		Name    string     `json:"name"`
+	}
+	options := append(slices.Clip(JsonOptions), NumericOptions...)
+	payload := `{"balance": 123456789012345678901234567890, "rate": "1.5", "share": "1/3"}`
+
+	// Act
+	p := Nullify(Account{}, options...)
+	errUnmarshal := json.Unmarshal([]byte(payload), p)
+	data, errMarshal := Marshal(p)
+	var account Account
+	errCopy := CopyMatching(p, &account)
+	p.(*struct {
+		Balance *big.Int   `json:"balance"`
+		Rate    *big.Float `json:"rate"`
+		Share   *big.Rat   `json:"share"`
+		Name    *string    `json:"name"`
+	}).Balance.SetInt64(1)
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(&struct {
+		Balance *big.Int   `json:"balance"`
+		Rate    *big.Float `json:"rate"`
+		Share   *big.Rat   `json:"share"`
+		Name    *string    `json:"name"`
+	}{}), reflect.TypeOf(p))
+	assert.NoError(t, errUnmarshal)
+	assert.NoError(t, errMarshal)
+	assert.JSONEq(t, payload, string(data))
+	assert.NoError(t, errCopy)
+	assert.Equal(t, "123456789012345678901234567890", account.Balance.String())
+	assert.Equal(t, "1.5", account.Rate.String())
+	assert.Equal(t, "1/3", account.Share.String())
+}
+
+func TestNullify_SkipUnserializable(t *testing.T) {
+	// Arrange
+	type Job struct {
+		Name     string              `json:"name"`
+		Done     chan struct{}       `json:"-"`
+		Callback func()              `json:"-"`
+		Handle   unsafe.Pointer      `json:"-"`
+		Next     *func() error       `json:"-"`
+		Hooks    []func()            `json:"-"`
+		Channels map[string]chan int `json:"-"`
+		Handles  [2]unsafe.Pointer   `json:"-"`
+		ByFunc   map[*func()]string  `json:"-"`
+		Nested   [][]func()          `json:"-"`
+		Labels   map[string][]string `json:"labels"`
+	}
+
+	tests := map[string]struct {
+		Options []option
+		Fields  []string
+	}{
+		"default": {
+			Options: nil,
+			Fields: []string{"Name", "Done", "Callback", "Handle", "Next", "Hooks", "Channels", "Handles", "ByFunc",
+				"Nested", "Labels"},
+		},
+		"SkipUnserializable": {Options: []option{SkipUnserializable{Value: true}}, Fields: []string{"Name", "Labels"}},
+		"JsonOptions":        {Options: JsonOptions, Fields: []string{"Name", "Labels"}},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Job{}, testData.Options...)
+
+			// Assert
+			typ := reflect.TypeOf(p).Elem()
+			fields := make([]string, typ.NumField())
+			for i := range fields {
+				fields[i] = typ.Field(i).Name
+			}
+			assert.Equal(t, testData.Fields, fields)
+		})
+	}
+}
+
+func TestNullify_BareContainers(t *testing.T) {
+	// Arrange
+	type Item struct {
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	tests := map[string]struct {
+		Options  []option
+		Expected any
+	}{
+		"default": {
+			Options: nil,
+			Expected: &struct {
+				Tags   *[]*string           `json:"tags"`
+				Labels *map[*string]*string `json:"labels"`
+			}{},
+		},
+		"BareContainers": {
+			Options: []option{BareContainers{Value: true}},
+			Expected: &struct {
+				Tags   []*string           `json:"tags"`
+				Labels map[*string]*string `json:"labels"`
+			}{},
+		},
+		"BareContainers without element nullification": {
+			Options: []option{
+				BareContainers{Value: true},
+				NullifySliceElem{Value: false},
+				NullifyMapElem{Value: false},
+				NullifyMapKey{Value: false},
+			},
+			Expected: &struct {
+				Tags   []string          `json:"tags"`
+				Labels map[string]string `json:"labels"`
+			}{},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Item{}, testData.Options...)
+
+			// Assert
+			assert.Equal(t, reflect.TypeOf(testData.Expected), reflect.TypeOf(p))
+		})
+	}
+}
+
+func TestNullify_BareContainersTopLevel(t *testing.T) {
+	// Act
+	p := Nullify([]string{}, BareContainers{Value: true})
+
+	// Assert
+	assert.IsType(t, &[]*string{}, p)
+}
+
+func TestNullify_BareContainersPresence(t *testing.T) {
+	// Arrange
+	type Item struct {
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+	item := Item{Tags: []string{"a"}, Labels: map[string]string{"k": "v"}}
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"tags": ["b"]}`), &item, BareContainers{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Item{Tags: []string{"b"}, Labels: map[string]string{"k": "v"}}, item)
+	assert.Equal(t, map[string]any{"tags": []any{"b"}}, Flatten(presence, BareContainers{Value: true}))
+}
+
+func TestNullify_NullifyStructFields(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Person struct {
+		Home Address  `json:"home"`
+		Work *Address `json:"work"`
+	}
+
+	// Act
+	p := Nullify(Person{}, NullifyStructFields{Value: false})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(&struct {
+		Home struct {
+			City *string `json:"city"`
+		} `json:"home"`
+		Work *struct {
+			City *string `json:"city"`
+		} `json:"work"`
+	}{}), reflect.TypeOf(p))
+}
+
+func TestNullify_NullifyStructFieldsPresence(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name string  `json:"name"`
+		Home Address `json:"home"`
+	}
+	person := Person{Name: "alice", Home: Address{Street: "Main St", City: "Springfield"}}
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"home": {"city": "Shelbyville"}}`), &person, NullifyStructFields{Value: false})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Person{Name: "alice", Home: Address{Street: "Main St", City: "Shelbyville"}}, person)
+	assert.Equal(t, map[string]any{"home.city": "Shelbyville"}, Flatten(presence))
+}
+
+func TestNullify_ValueResult(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+
+	// Act
+	p := Nullify(Person{}, ValueResult{Value: true})
+
+	// Assert
+	assert.Equal(t, struct {
+		Name *string `json:"name"`
+	}{}, p)
+}
+
+func TestTypeOf(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	type nullified = struct {
+		Name *string `json:"name"`
+	}
+
+	tests := map[string]struct {
+		Obj      any
+		Options  []option
+		Expected reflect.Type
+	}{
+		"nil":         {Obj: nil, Expected: nil},
+		"pointer":     {Obj: Person{}, Expected: reflect.TypeOf(&nullified{})},
+		"ValueResult": {Obj: Person{}, Options: []option{ValueResult{Value: true}}, Expected: reflect.TypeOf(nullified{})},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			typ := TypeOf(testData.Obj, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Expected, typ)
+		})
+	}
+}
+
+func TestTypeOf_Embedded(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city"`
+	}
+	typ := reflect.StructOf([]reflect.StructField{
+		{Name: "Address", Type: TypeOf(Address{}, ValueResult{Value: true}), Tag: `json:"address"`},
+	})
+	v := reflect.New(typ).Interface()
+
+	// Act
+	err := json.Unmarshal([]byte(`{"address": {"city": "Springfield"}}`), v)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"address.city": "Springfield"}, Flatten(v))
+}
+
+func TestNullifyInto(t *testing.T) {
+	type Person struct {
+		Name string
+	}
+	reused := Nullify(Person{})
+	*reused.(*struct{ Name *string }) = struct{ Name *string }{Name: new(string)}
+
+	tests := map[string]struct {
+		Dst    any
+		Reused bool
+	}{
+		"empty slot":       {Dst: new(any)},
+		"slot of new type": {Dst: func() *any { v := any("other"); return &v }()},
+		"reused slot":      {Dst: func() *any { v := reused; return &v }(), Reused: true},
+		"pointer":          {Dst: reused, Reused: true},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := NullifyInto(testData.Dst, Person{})
+
+			// Assert
+			assert.Nil(t, err)
+			result := testData.Dst
+			if slot, ok := testData.Dst.(*any); ok {
+				result = *slot
+			}
+			assert.Equal(t, Nullify(Person{}), result)
+			assert.Equal(t, testData.Reused, result == reused)
+		})
+	}
+}
+
+func TestNullifyInto_Errors(t *testing.T) {
+	tests := map[string]struct {
+		Dst          any
+		Src          any
+		ErrorMessage string
+	}{
+		"nil src":      {Dst: new(any), Src: nil, ErrorMessage: "nullify: cannot nullify nil"},
+		"wrong type":   {Dst: new(string), Src: 1, ErrorMessage: "nullify: cannot nullify int into *string"},
+		"nil pointer":  {Dst: (*int)(nil), Src: 1, ErrorMessage: "nullify: cannot nullify int into *int"},
+		"nil dst":      {Dst: nil, Src: 1, ErrorMessage: "nullify: cannot nullify int into <nil>"},
+		"nil any slot": {Dst: (*any)(nil), Src: 1, ErrorMessage: "nullify: cannot nullify int into *interface {}"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := NullifyInto(testData.Dst, testData.Src)
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestNullify_InterfaceElem(t *testing.T) {
+	tests := map[string]struct {
+		Input    any
+		Options  []option
+		Expected reflect.Type
+	}{
+		"slice":            {Input: []any{}, Expected: reflect.TypeOf([]any{})},
+		"array":            {Input: [2]any{}, Expected: reflect.TypeOf([2]any{})},
+		"map":              {Input: map[string]any{}, Expected: reflect.TypeOf(map[*string]any{})},
+		"named interface":  {Input: []shape{}, Expected: reflect.TypeOf([]shape{})},
+		"nullify elements": {Input: map[string]any{}, Options: []option{NullifyInterfaceElem{Value: true}}, Expected: reflect.TypeOf(map[*string]*any{})},
+		"interface impl": {Input: []shape{}, Options: []option{WithInterfaceImpl[shape, square]()}, Expected: reflect.TypeOf([]*struct {
+			Side *float64 `json:"side"`
+		}{})},
+		"not nullified elements": {Input: []any{}, Options: JsonOptions, Expected: reflect.TypeOf([]any{})},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(testData.Input, testData.Options...)
+
+			// Assert
+			assert.Equal(t, testData.Expected, reflect.TypeOf(p).Elem())
+		})
+	}
+}
+
+func TestNullify_InterfaceElem_Unmarshal(t *testing.T) {
+	// Arrange
+	type Event struct {
+		Tags    []any          `json:"tags"`
+		Payload map[string]any `json:"payload"`
+	}
+	p := Nullify(Event{}, SafeMapKeys{Value: true})
+
+	// Act
+	err := json.Unmarshal([]byte(`{"tags": ["a", 1, null, {"b": true}], "payload": {"n": 1.5, "s": "x", "l": [1]}}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	var event Event
+	assert.Nil(t, CopyMatching(p, &event))
+	assert.Equal(t, Event{
+		Tags:    []any{"a", float64(1), nil, map[string]any{"b": true}},
+		Payload: map[string]any{"n": 1.5, "s": "x", "l": []any{float64(1)}},
+	}, event)
+}
+
+func TestNullify_OnRecursion(t *testing.T) {
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	tests := map[string]struct {
+		Recursion Recursion
+		Expected  reflect.Type
+	}{
+		"any": {
+			Recursion: RecursionAny,
+			Expected: reflect.TypeOf(struct {
+				Value    *int    `json:"value"`
+				Next     *any    `json:"next"`
+				Children *[]*any `json:"children"`
+			}{}),
+		},
+		"original": {
+			Recursion: RecursionOriginal,
+			Expected: reflect.TypeOf(struct {
+				Value    *int     `json:"value"`
+				Next     *Node    `json:"next"`
+				Children *[]*Node `json:"children"`
+			}{}),
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			p := Nullify(Node{}, OnRecursion{Value: testData.Recursion})
+
+			// Assert
+			assert.Equal(t, testData.Expected, reflect.TypeOf(p).Elem())
+		})
+	}
+}
+
+func TestNullify_OnRecursion_Memoized(t *testing.T) {
+	// Arrange
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	type Root struct {
+		A Node  `json:"a"`
+		P *Node `json:"p"`
+	}
+
+	// Act
+	p := Nullify(Root{}, OnRecursion{Value: RecursionAny})
+
+	// Assert
+	node := reflect.TypeOf(Nullify(Node{}, OnRecursion{Value: RecursionAny}))
+	assert.Equal(t, node, reflect.TypeOf(p).Elem().Field(0).Type)
+	assert.Equal(t, node, reflect.TypeOf(p).Elem().Field(1).Type)
+}
+
+func TestNullify_OnRecursion_Unmarshal(t *testing.T) {
+	// Arrange
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	p := Nullify(Node{}, OnRecursion{Value: RecursionOriginal})
+
+	// Act
+	err := json.Unmarshal([]byte(`{"value": 1, "next": {"value": 2}}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	var node Node
+	assert.Nil(t, CopyMatching(p, &node))
+	assert.Equal(t, Node{Value: 1, Next: &Node{Value: 2}}, node)
+}
+
+func TestNullify_OnRecursion_Panics(t *testing.T) {
+	// Arrange
+	type Node struct {
+		Value    int    `json:"value"`
+		Next     *Node  `json:"next"`
+		Children []Node `json:"children"`
+	}
+
+	// Act & Assert
+	assert.PanicsWithValue(t, "nullify: nullify.Node is recursive, use OnRecursion to substitute recursive references", func() {
+		Nullify(Node{})
+	})
+}
+
+func TestNullify_Repeated(t *testing.T) {
+	// Arrange
+	type Money struct {
+		Amount []byte
+	}
+	type Order struct {
+		Price Money
+		Tax   Money
+	}
+
+	// Act
+	p := Nullify(Order{})
+	withFieldOptions := Nullify(Order{}, WithFieldOptions("Tax", BytesAsString{Value: true}))
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(p).Elem().Field(0).Type, reflect.TypeOf(p).Elem().Field(1).Type)
+	assert.Equal(t, reflect.TypeOf((*[]*uint8)(nil)), reflect.TypeOf(withFieldOptions).Elem().Field(0).Type.Elem().Field(0).Type)
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), reflect.TypeOf(withFieldOptions).Elem().Field(1).Type.Elem().Field(0).Type)
+}
+
+func benchmarkNullify(b *testing.B, input any) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Nullify(input)
+	}
+}
+
+func BenchmarkNullify_Wide(b *testing.B) {
+	fields := make([]reflect.StructField, 200)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: "Field" + strconv.Itoa(i), Type: reflect.TypeOf("")}
+	}
+	benchmarkNullify(b, reflect.New(reflect.StructOf(fields)).Elem().Interface())
+}
+
+func BenchmarkNullify_Deep(b *testing.B) {
+	type Address struct {
+		Street  string            `json:"street"`
+		City    string            `json:"city"`
+		Country string            `json:"country"`
+		Labels  map[string]string `json:"labels"`
+	}
+	type Deep struct {
+		Level1 struct {
+			Level2 struct {
+				Level3 struct {
+					Level4 struct {
+						Address Address `json:"address"`
+					} `json:"level4"`
+				} `json:"level3"`
+			} `json:"level2"`
+		} `json:"level1"`
+	}
+
+	benchmarkNullify(b, Deep{})
+}
+
+func BenchmarkNullify_Repeated(b *testing.B) {
+	type Money struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+
+	fields := make([]reflect.StructField, 50)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: "Price" + strconv.Itoa(i), Type: reflect.TypeOf(Money{})}
+	}
+	benchmarkNullify(b, reflect.New(reflect.StructOf(fields)).Elem().Interface())
+}
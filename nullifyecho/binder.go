@@ -0,0 +1,54 @@
+// Package nullifyecho plugs nullify based decoding into the binding mechanism of Echo, such that binding a JSON
+// body detects missing fields without changing the request struct.
+package nullifyecho
+
+import (
+	"encoding/json"
+	"github.com/Emptyless/nullify"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Binder is an echo.Binder that decodes JSON bodies through the nullified type of the target with
+// nullify.JsonOptions. Path parameters are bound with the echo.DefaultBinder, as are bodies of other content
+// types. The nullified value is validated with the Validator of the Echo instance (if any) before the present
+// fields are copied into the target, such that a required rule fails for fields that were not sent rather than
+// for fields holding the zero value.
+//
+// Use it by setting `e.Binder = nullifyecho.Binder{}`.
+type Binder struct{}
+
+func (b Binder) Bind(i any, c echo.Context) error {
+	var defaultBinder echo.DefaultBinder
+	if err := defaultBinder.BindPathParams(c, i); err != nil {
+		return err
+	}
+
+	req := c.Request()
+	if req.ContentLength == 0 || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return defaultBinder.Bind(i, c)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	presence := nullify.Nullify(i, nullify.JsonOptions...)
+	if err := json.Unmarshal(body, presence); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	if c.Echo().Validator != nil {
+		if err := c.Validate(presence); err != nil {
+			return err
+		}
+	}
+
+	if err := nullify.CopyMatching(presence, i, nullify.JsonOptions...); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
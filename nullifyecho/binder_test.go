@@ -0,0 +1,71 @@
+package nullifyecho
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	ID   string `param:"id" json:"-"`
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"required"`
+}
+
+type structValidator struct {
+	validate *validator.Validate
+}
+
+func (v structValidator) Validate(i any) error {
+	return v.validate.Struct(i)
+}
+
+func TestBinder_Bind(t *testing.T) {
+	tests := map[string]struct {
+		Body         string
+		Person       person
+		ErrorMessage string
+	}{
+		"zero value": {
+			Body:   `{"name": "alice", "age": 0}`,
+			Person: person{ID: "1", Name: "alice", Age: 0},
+		},
+		"missing": {
+			Body:         `{"name": "alice"}`,
+			ErrorMessage: "Key: 'Age' Error:Field validation for 'Age' failed on the 'required' tag",
+		},
+		"malformed": {
+			Body:         `{`,
+			ErrorMessage: "code=400",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			e := echo.New()
+			e.Validator = structValidator{validate: validator.New()}
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testData.Body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			var p person
+
+			// Act
+			err := Binder{}.Bind(&p, c)
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, testData.Person, p)
+			} else {
+				assert.ErrorContains(t, err, testData.ErrorMessage)
+			}
+		})
+	}
+}
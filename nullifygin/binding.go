@@ -0,0 +1,52 @@
+// Package nullifygin plugs nullify based decoding into the binding mechanism of Gin, such that binding a JSON
+// body detects missing fields without changing the request struct.
+package nullifygin
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Emptyless/nullify"
+	"github.com/gin-gonic/gin/binding"
+	"io"
+	"net/http"
+)
+
+// JSON binds JSON bodies through the nullified type of the target, e.g. `c.ShouldBindWith(&req, nullifygin.JSON)`
+var JSON binding.BindingBody = jsonBinding{}
+
+// jsonBinding decodes the body into the nullified type of the target with nullify.JsonOptions and validates the
+// nullified value with binding.Validator, such that a `binding:"required"` tag fails for fields that were not
+// sent rather than for fields holding the zero value. Only after validation succeeds the present fields are
+// copied into the target.
+type jsonBinding struct{}
+
+func (jsonBinding) Name() string {
+	return "nullify-json"
+}
+
+func (b jsonBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return b.BindBody(body, obj)
+}
+
+func (jsonBinding) BindBody(body []byte, obj any) error {
+	presence := nullify.Nullify(obj, nullify.JsonOptions...)
+	if err := json.Unmarshal(body, presence); err != nil {
+		return err
+	}
+
+	if binding.Validator != nil {
+		if err := binding.Validator.ValidateStruct(presence); err != nil {
+			return err
+		}
+	}
+
+	return nullify.CopyMatching(presence, obj, nullify.JsonOptions...)
+}
@@ -0,0 +1,84 @@
+package nullifygin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	Name string `json:"name" binding:"required"`
+	Age  int    `json:"age" binding:"required"`
+}
+
+func TestJSON(t *testing.T) {
+	tests := map[string]struct {
+		Body         string
+		Person       person
+		ErrorMessage string
+	}{
+		"zero value": {
+			Body:   `{"name": "alice", "age": 0}`,
+			Person: person{Name: "alice", Age: 0},
+		},
+		"missing": {
+			Body:         `{"name": "alice"}`,
+			ErrorMessage: "Key: 'Age' Error:Field validation for 'Age' failed on the 'required' tag",
+		},
+		"malformed": {
+			Body:         `{`,
+			ErrorMessage: "unexpected end of JSON input",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testData.Body))
+			var p person
+
+			// Act
+			err := c.ShouldBindWith(&p, JSON)
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, testData.Person, p)
+			} else {
+				assert.ErrorContains(t, err, testData.ErrorMessage)
+				assert.Equal(t, person{}, p)
+			}
+		})
+	}
+}
+
+func TestJSON_BindBody(t *testing.T) {
+	// Arrange
+	var p person
+
+	// Act
+	err := JSON.BindBody([]byte(`{"name": "bob", "age": 3}`), &p)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, person{Name: "bob", Age: 3}, p)
+	assert.Equal(t, "nullify-json", JSON.Name())
+}
+
+func TestJSON_BindNilBody(t *testing.T) {
+	// Arrange
+	var p person
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Body = nil
+
+	// Act
+	err := JSON.Bind(req, &p)
+
+	// Assert
+	assert.EqualError(t, err, "invalid request")
+}
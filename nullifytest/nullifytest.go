@@ -0,0 +1,174 @@
+// Package nullifytest provides test assertions on nullified values, addressing fields with dotted paths of Go
+// field names or json names (e.g. "Address.City" or "address.city"), with optional indices ("Tags[0]").
+package nullifytest
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TestingT is the subset of testing.TB used by the assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertSet asserts that the field at path of the nullified value v is set (non-nil)
+func AssertSet(t TestingT, v any, path string) bool {
+	t.Helper()
+	value, err := lookup(v, path)
+	if err != nil {
+		t.Errorf("nullifytest: %s", err)
+		return false
+	}
+	if !isSet(value) {
+		t.Errorf("nullifytest: expected %q to be set", path)
+		return false
+	}
+	return true
+}
+
+// AssertUnset asserts that the field at path of the nullified value v is unset (nil)
+func AssertUnset(t TestingT, v any, path string) bool {
+	t.Helper()
+	value, err := lookup(v, path)
+	if err != nil {
+		t.Errorf("nullifytest: %s", err)
+		return false
+	}
+	if isSet(value) {
+		t.Errorf("nullifytest: expected %q to be unset, got %v", path, deref(value).Interface())
+		return false
+	}
+	return true
+}
+
+// AssertEqual asserts that the field at path of the nullified value v is set and, after dereferencing, equal to
+// expected. Basic values are converted to the type of the field first, such that e.g. an untyped constant can be
+// compared against an int64 field.
+func AssertEqual(t TestingT, v any, path string, expected any) bool {
+	t.Helper()
+	if !AssertSet(t, v, path) {
+		return false
+	}
+
+	value, _ := lookup(v, path)
+	actual := deref(value)
+	want := reflect.ValueOf(expected)
+	if want.IsValid() && want.Type() != actual.Type() && isBasic(want.Kind()) && isBasic(actual.Kind()) &&
+		isString(want.Kind()) == isString(actual.Kind()) && want.Type().ConvertibleTo(actual.Type()) {
+		want = want.Convert(actual.Type())
+	}
+
+	if !want.IsValid() || !reflect.DeepEqual(want.Interface(), actual.Interface()) {
+		t.Errorf("nullifytest: expected %q to equal %#v, got %#v", path, expected, actual.Interface())
+		return false
+	}
+	return true
+}
+
+// lookup resolves path in v, returning the (possibly nil) value at the path
+func lookup(v any, path string) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	for _, segment := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(segment, "[")
+
+		if name != "" {
+			value = deref(value)
+			if value.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %q in %q: not a struct", name, path)
+			}
+			field, ok := fieldByName(value.Type(), name)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %q in %q: no such field", name, path)
+			}
+			var err error
+			if value, err = value.FieldByIndexErr(field.Index); err != nil {
+				// promoted through a nil embedded pointer
+				return reflect.Value{}, fmt.Errorf("cannot resolve %q in %q: embedded struct not set", name, path)
+			}
+		}
+
+		for rest != "" {
+			index, remainder, ok := strings.Cut(rest, "]")
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %q in %q: missing ]", segment, path)
+			}
+			rest = strings.TrimPrefix(remainder, "[")
+
+			value = deref(value)
+			var err error
+			if value, err = elem(value, index); err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %q in %q: %w", segment, path, err)
+			}
+		}
+	}
+	return value, nil
+}
+
+// elem returns the element at index of a slice, array or string-keyed map
+func elem(v reflect.Value, index string) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index %s out of range", index)
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if key := deref(iter.Key()); key.Kind() == reflect.String && key.String() == index {
+				return iter.Value(), nil
+			}
+		}
+		return reflect.Value{}, fmt.Errorf("key %s not found", index)
+	default:
+		return reflect.Value{}, fmt.Errorf("not indexable")
+	}
+}
+
+// fieldByName returns the field with the Go name, falling back to the field with the json name
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := t.FieldByName(name); ok {
+		return field, true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); jsonName == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// deref dereferences pointers and interfaces, stopping at nil
+func deref(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// isSet returns true if v is valid and not a nil pointer, interface, slice or map
+func isSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return false
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return !v.IsNil()
+	default:
+		return true
+	}
+}
+
+// isBasic returns true for boolean, numeric and string kinds
+func isBasic(kind reflect.Kind) bool {
+	return kind >= reflect.Bool && kind <= reflect.Complex128 || kind == reflect.String
+}
+
+// isString returns true for the string kind
+func isString(kind reflect.Kind) bool {
+	return kind == reflect.String
+}
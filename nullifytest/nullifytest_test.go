@@ -0,0 +1,120 @@
+package nullifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Emptyless/nullify"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// recorder records the failures reported by the assertions
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type address struct {
+	City string `json:"city"`
+}
+
+type person struct {
+	Name    string            `json:"name"`
+	Age     int64             `json:"age"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Address address           `json:"address"`
+}
+
+func decode(t *testing.T, payload string) any {
+	p := nullify.Nullify(person{})
+	if err := json.Unmarshal([]byte(payload), p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestAssertions(t *testing.T) {
+	// Arrange
+	p := decode(t, `{"name": "bob", "age": 42, "tags": ["a"], "labels": {"k": "v"}, "address": {"city": "Springfield"}}`)
+
+	// Act & Assert
+	AssertSet(t, p, "Name")
+	AssertSet(t, p, "address.city")
+	AssertEqual(t, p, "Name", "bob")
+	AssertEqual(t, p, "Age", 42)
+	AssertEqual(t, p, "tags[0]", "a")
+	AssertEqual(t, p, "labels[k]", "v")
+	AssertEqual(t, p, "Address.City", "Springfield")
+}
+
+func TestAssertions_Failures(t *testing.T) {
+	// Arrange
+	p := decode(t, `{"name": "bob", "address": {}}`)
+
+	tests := map[string]struct {
+		Assert func(r *recorder) bool
+		Error  string
+	}{
+		"set":           {Assert: func(r *recorder) bool { return AssertSet(r, p, "Age") }, Error: `nullifytest: expected "Age" to be set`},
+		"unset":         {Assert: func(r *recorder) bool { return AssertUnset(r, p, "name") }, Error: `nullifytest: expected "name" to be unset, got bob`},
+		"equal":         {Assert: func(r *recorder) bool { return AssertEqual(r, p, "Name", "alice") }, Error: `nullifytest: expected "Name" to equal "alice", got "bob"`},
+		"unknown field": {Assert: func(r *recorder) bool { return AssertSet(r, p, "Unknown") }, Error: `nullifytest: cannot resolve "Unknown" in "Unknown": no such field`},
+		"nested unset":  {Assert: func(r *recorder) bool { return AssertSet(r, p, "address.city") }, Error: `nullifytest: expected "address.city" to be set`},
+		"out of range":  {Assert: func(r *recorder) bool { return AssertSet(r, p, "Tags[0]") }, Error: `nullifytest: cannot resolve "Tags[0]" in "Tags[0]": not indexable`},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			r := &recorder{}
+
+			// Act
+			ok := testData.Assert(r)
+
+			// Assert
+			assert.False(t, ok)
+			assert.Equal(t, []string{testData.Error}, r.errors)
+		})
+	}
+}
+
+func TestAssertUnset(t *testing.T) {
+	// Arrange
+	p := decode(t, `{}`)
+
+	// Act & Assert
+	AssertUnset(t, p, "Name")
+	AssertUnset(t, p, "Address")
+	AssertUnset(t, p, "tags")
+}
+
+type Audit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type document struct {
+	*Audit
+	Title string `json:"title"`
+}
+
+func TestAssertSet_NilEmbedded(t *testing.T) {
+	// Arrange
+	p := nullify.Nullify(document{})
+	if err := json.Unmarshal([]byte(`{"title": "x"}`), p); err != nil {
+		t.Fatal(err)
+	}
+	r := &recorder{}
+
+	// Act
+	ok := AssertSet(r, p, "CreatedBy")
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, []string{`nullifytest: cannot resolve "CreatedBy" in "CreatedBy": embedded struct not set`}, r.errors)
+}
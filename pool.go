@@ -0,0 +1,45 @@
+package nullify
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Pool reuses instances of the nullified type of a prototype through a sync.Pool, avoiding an allocation per
+// decoded request. The nullified type is computed once when the Pool is created.
+type Pool struct {
+	typ  reflect.Type
+	pool sync.Pool
+}
+
+// NewPool returns a Pool of instances of the nullified type of prototype, options are passed to Nullify.
+// It returns nil if prototype is the nil interface.
+func NewPool(prototype any, options ...option) *Pool {
+	instance := newNullified(prototype, options...)
+	if instance == nil {
+		return nil
+	}
+
+	p := &Pool{typ: reflect.TypeOf(instance).Elem()}
+	p.pool.New = func() any {
+		return reflect.New(p.typ).Interface()
+	}
+	return p
+}
+
+// Get returns a zeroed instance of the nullified type, equivalent to the result of Nullify
+func (p *Pool) Get() any {
+	return p.pool.Get()
+}
+
+// Put zeroes v and returns it to the pool. Values of another type than the nullified type are ignored. v must not
+// be used after calling Put.
+func (p *Pool) Put(v any) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Type().Elem() != p.typ {
+		return
+	}
+
+	val.Elem().SetZero()
+	p.pool.Put(v)
+}
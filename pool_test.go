@@ -0,0 +1,80 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	pool := NewPool(Person{})
+
+	// Act
+	v := pool.Get()
+	err := json.Unmarshal([]byte(`{"name": "alice"}`), v)
+	pool.Put(v)
+	reused := pool.Get()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{})), reflect.TypeOf(reused))
+	assert.True(t, reflect.ValueOf(reused).Elem().Field(0).IsNil())
+}
+
+func TestPool_PutIgnoresOtherTypes(t *testing.T) {
+	// Arrange
+	pool := NewPool(struct{ Name string }{})
+
+	// Act
+	pool.Put("string")
+	pool.Put(nil)
+	v := pool.Get()
+
+	// Assert
+	assert.Equal(t, reflect.Pointer, reflect.TypeOf(v).Kind())
+	assert.Equal(t, reflect.Struct, reflect.TypeOf(v).Elem().Kind())
+}
+
+func TestNewPool_Nil(t *testing.T) {
+	assert.Nil(t, NewPool(nil))
+}
+
+func TestNewPool_ValueResult(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	pool := NewPool(Person{}, ValueResult{Value: true})
+
+	// Act
+	v := pool.Get()
+	err := json.Unmarshal([]byte(`{"name": "alice"}`), v)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{})), reflect.TypeOf(v))
+}
+
+func BenchmarkPool(b *testing.B) {
+	type Address struct {
+		Street  string            `json:"street"`
+		City    string            `json:"city"`
+		Country string            `json:"country"`
+		Labels  map[string]string `json:"labels"`
+	}
+
+	pool := NewPool(Address{})
+	data := []byte(`{"street": "Main St", "city": "Springfield"}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v := pool.Get()
+		_ = json.Unmarshal(data, v)
+		pool.Put(v)
+	}
+}
@@ -0,0 +1,36 @@
+package nullify
+
+import (
+	"slices"
+	"sync"
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string][]option{
+		"json":      JsonOptions,
+		"jsonv2":    JsonV2Options,
+		"validator": ValidatorOptions,
+		"protojson": ProtoJsonOptions,
+		"sql":       SqlOptions,
+		"numeric":   NumericOptions,
+	}
+)
+
+// RegisterPreset registers options under name, such that applications and libraries can share configurations by
+// name, e.g. `nullify.Nullify(t, nullify.Preset("strict-api")...)`. Registering an existing name replaces it,
+// including the built-in presets json (JsonOptions), jsonv2 (JsonV2Options), validator (ValidatorOptions),
+// protojson (ProtoJsonOptions), sql (SqlOptions) and numeric (NumericOptions). The option variables themselves are
+// not modified.
+func RegisterPreset(name string, options ...option) {
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+	presets[name] = slices.Clone(options)
+}
+
+// Preset returns a copy of the options registered under name, nil if no preset is registered under that name
+func Preset(name string) []option {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	return slices.Clone(presets[name])
+}
@@ -0,0 +1,53 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPreset(t *testing.T) {
+	tests := map[string]struct {
+		Name     string
+		Expected []option
+	}{
+		"json":      {Name: "json", Expected: JsonOptions},
+		"jsonv2":    {Name: "jsonv2", Expected: JsonV2Options},
+		"validator": {Name: "validator", Expected: ValidatorOptions},
+		"protojson": {Name: "protojson", Expected: ProtoJsonOptions},
+		"sql":       {Name: "sql", Expected: SqlOptions},
+		"numeric":   {Name: "numeric", Expected: NumericOptions},
+		"unknown":   {Name: "unknown", Expected: nil},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			options := Preset(testData.Name)
+
+			// Assert
+			assert.Equal(t, testData.Expected, options)
+		})
+	}
+}
+
+func TestRegisterPreset(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		RegisterPreset("json", JsonOptions...)
+		presetsMu.Lock()
+		delete(presets, "strict-api")
+		presetsMu.Unlock()
+	})
+	strict := []option{BytesAsString{Value: true}, ValidateRequired{Value: true}}
+
+	// Act
+	RegisterPreset("strict-api", strict...)
+	RegisterPreset("json", OmitEmpty{Value: true})
+	options := Preset("strict-api")
+	options[0] = OmitNil{Value: true}
+
+	// Assert
+	assert.Equal(t, strict, Preset("strict-api"))
+	assert.Equal(t, []option{OmitEmpty{Value: true}}, Preset("json"))
+	assert.Equal(t, BytesAsString{Value: true}, JsonOptions[0])
+}
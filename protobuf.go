@@ -0,0 +1,67 @@
+package nullify
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Protobuf if true (default false) adapts nullification to structs generated from protobuf by protoc-gen-go, such
+// that the result decodes their protojson representation with encoding/json:
+//   - the unexported internal fields of messages (state, sizeCache, unknownFields) are dropped
+//   - oneof fields (tagged `protobuf_oneof`) are left untouched
+//   - enums and well-known types (e.g. timestamppb.Timestamp) become *any, as protojson represents them as names
+//     and formatted strings respectively
+//   - the json name is taken from the json= option of the protobuf tag (lowerCamelCase), as used by protojson
+//   - 64-bit integer fields (int64, uint64, sint64, fixed64 and sfixed64) get the `,string` json option, as
+//     protojson represents them as strings. Repeated fields and map values are not covered by the option.
+type Protobuf struct {
+	Value bool
+}
+
+func (o Protobuf) update(cfg config) config {
+	cfg.protobuf = o.Value
+	return cfg
+}
+
+// isProtoEnum returns true if t is an enum generated by protoc-gen-go, i.e. an int32 implementing
+// protoreflect.Enum
+func isProtoEnum(t reflect.Type) bool {
+	if t.Kind() != reflect.Int32 || t.Name() == "" {
+		return false
+	}
+	_, number := t.MethodByName("Number")
+	_, descriptor := t.MethodByName("Descriptor")
+	return number && descriptor
+}
+
+// isProto64 returns true if t is a (pointer to a) 64-bit integer, which protojson represents as a string
+func isProto64(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64
+}
+
+// isWellKnownProto returns true if t is a message of the protobuf well-known types, e.g. timestamppb.Timestamp
+func isWellKnownProto(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !strings.HasPrefix(t.PkgPath(), "google.golang.org/protobuf/types/known/") {
+		return false
+	}
+	_, ok := reflect.PointerTo(t).MethodByName("ProtoReflect")
+	return ok
+}
+
+// protoJsonName returns the json= option of the protobuf tag, false if there is none
+func protoJsonName(tag reflect.StructTag) (string, bool) {
+	value, ok := tag.Lookup("protobuf")
+	if !ok {
+		return "", false
+	}
+
+	for _, opt := range strings.Split(value, ",") {
+		if name, ok := strings.CutPrefix(opt, "json="); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,102 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"reflect"
+	"testing"
+)
+
+// protoKind mimics an enum generated by protoc-gen-go
+type protoKind int32
+
+func (k protoKind) Number() int32      { return int32(k) }
+func (k protoKind) Descriptor() string { return "kind" }
+
+// isProtoPerson_Contact mimics the interface of a oneof generated by protoc-gen-go
+type isProtoPerson_Contact interface {
+	isProtoPerson_Contact()
+}
+
+type ProtoPerson_Email struct {
+	Email string `protobuf:"bytes,4,opt,name=email,proto3,oneof"`
+}
+
+func (*ProtoPerson_Email) isProtoPerson_Contact() {}
+
+// protoPerson mimics a message generated by protoc-gen-go
+type protoPerson struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	DisplayName string                `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Kind        protoKind             `protobuf:"varint,2,opt,name=kind,proto3,enum=pkg.Kind" json:"kind,omitempty"`
+	Avatar      []byte                `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Contact     isProtoPerson_Contact `protobuf_oneof:"contact"`
+}
+
+func TestNullify_ProtoJsonOptions(t *testing.T) {
+	// Act
+	p := Nullify(protoPerson{}, ProtoJsonOptions...)
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(struct {
+		DisplayName *string               `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"displayName,omitempty"`
+		Kind        *any                  `protobuf:"varint,2,opt,name=kind,proto3,enum=pkg.Kind" json:"kind,omitempty"`
+		Avatar      string                `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+		Contact     isProtoPerson_Contact `protobuf_oneof:"contact"`
+	}{}), reflect.TypeOf(p).Elem())
+}
+
+func TestNullify_ProtoJsonOptions_Unmarshal(t *testing.T) {
+	// Arrange
+	p := Nullify(protoPerson{}, ProtoJsonOptions...)
+
+	// Act
+	err := json.Unmarshal([]byte(`{"displayName": "alice", "kind": "KIND_ADMIN", "avatar": "aGVsbG8="}`), p)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"DisplayName": "alice", "Kind": "KIND_ADMIN", "Avatar": "aGVsbG8="}, plainOf(p))
+}
+
+func TestNullify_ProtoJsonOptions_RoundTrip(t *testing.T) {
+	// Arrange
+	original := &descriptorpb.UninterpretedOption{
+		IdentifierValue:  proto.String("id"),
+		PositiveIntValue: proto.Uint64(1 << 60),
+		NegativeIntValue: proto.Int64(-5),
+		DoubleValue:      proto.Float64(1.5),
+		StringValue:      []byte("hello"),
+	}
+	data, err := protojson.Marshal(original)
+	assert.NoError(t, err)
+	p := Nullify(descriptorpb.UninterpretedOption{}, ProtoJsonOptions...)
+
+	// Act
+	err = json.Unmarshal(data, p)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded descriptorpb.UninterpretedOption
+	options := append([]option{MatchFields{Value: MatchGoName}}, ProtoJsonOptions...) // original json tags are snake_case
+	assert.NoError(t, CopyMatching(p, &decoded, options...))
+	assert.True(t, proto.Equal(original, &decoded))
+
+	marshaled, err := Marshal(p)
+	assert.NoError(t, err)
+	var roundTripped descriptorpb.UninterpretedOption
+	assert.NoError(t, protojson.Unmarshal(marshaled, &roundTripped))
+	assert.True(t, proto.Equal(original, &roundTripped))
+}
+
+func TestIsProtoEnum(t *testing.T) {
+	// Act & Assert
+	assert.True(t, isProtoEnum(reflect.TypeOf(protoKind(0))))
+	assert.False(t, isProtoEnum(reflect.TypeOf(int32(0))))
+	assert.False(t, isWellKnownProto(reflect.TypeOf(protoPerson{})))
+}
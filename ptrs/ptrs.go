@@ -0,0 +1,20 @@
+// Package ptrs provides generic helpers for the pointer fields of nullified values
+package ptrs
+
+// Ptr returns a pointer to v
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or def if p is nil
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// IsSet returns true if p is not nil
+func IsSet[T any](p *T) bool {
+	return p != nil
+}
@@ -0,0 +1,40 @@
+package ptrs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPtr(t *testing.T) {
+	// Act
+	p := Ptr("bob")
+
+	// Assert
+	assert.Equal(t, "bob", *p)
+}
+
+func TestDeref(t *testing.T) {
+	tests := map[string]struct {
+		Pointer  *int
+		Expected int
+	}{
+		"nil": {Pointer: nil, Expected: 42},
+		"set": {Pointer: Ptr(0), Expected: 0},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			actual := Deref(testData.Pointer, 42)
+
+			// Assert
+			assert.Equal(t, testData.Expected, actual)
+		})
+	}
+}
+
+func TestIsSet(t *testing.T) {
+	// Act & Assert
+	assert.False(t, IsSet[string](nil))
+	assert.True(t, IsSet(Ptr("")))
+}
@@ -0,0 +1,104 @@
+package nullify
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HSetMap returns the fields of the nullified value that are set (non-nil) with their values encoded as strings,
+// ready to be passed to HSET: `rdb.HSet(ctx, key, nullify.HSetMap(patch))`. Unset fields are absent such that
+// a partially cached object only overwrites the fields that were provided.
+//
+// Field names are taken from the `redis` tag, falling back to the json name (following WithTagPriority). Fields tagged
+// `redis:"-"` are skipped. Nested structs are flattened into dotted names (e.g. address.street), embedded structs
+// without a name into their parent. Strings, numbers and booleans (1 or 0) are encoded like go-redis does,
+// encoding.TextMarshaler implementations (e.g. time.Time) by their text and other values (e.g. slices and maps) as
+// json.
+func HSetMap(nullified any, options ...option) map[string]any {
+	fields := map[string]any{}
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && isNullifiedStruct(v.Type()) {
+		hsetMap(fields, v, "", newConfig(options...))
+	}
+	return fields
+}
+
+// hsetMap adds the set fields of the nullified struct v to fields, prefixing names with the dotted path
+func hsetMap(fields map[string]any, v reflect.Value, path string, cfg config) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := redisName(field, cfg)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		if isNullifiedStruct(value.Type()) && field.Anonymous && !hasRedisName(field, cfg) {
+			hsetMap(fields, value, path, cfg) // flattened into its parent like Flatten does
+			continue
+		}
+		if isNullifiedStruct(value.Type()) {
+			hsetMap(fields, value, path+name+".", cfg)
+			continue
+		}
+
+		fields[path+name] = redisString(value)
+	}
+}
+
+// redisName returns the name of field from the redis tag or according to fieldName, false if the field is skipped
+func redisName(field reflect.StructField, cfg config) (string, bool) {
+	if name, _, _ := strings.Cut(field.Tag.Get("redis"), ","); name != "" {
+		return name, name != "-"
+	}
+	return fieldName(field, cfg)
+}
+
+// hasRedisName returns true if the name of field is given by a tag rather than being its Go field name
+func hasRedisName(field reflect.StructField, cfg config) bool {
+	redisName, _, _ := strings.Cut(field.Tag.Get("redis"), ",")
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	_, found := priorityName(field, cfg)
+	return redisName != "" || jsonName != "" || found
+}
+
+// redisString encodes the dereferenced value v as a string for a redis hash field
+func redisString(v reflect.Value) string {
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		if v.Bool() {
+			return "1"
+		}
+		return "0"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+	}
+
+	if b, err := json.Marshal(v.Interface()); err == nil {
+		return string(b)
+	}
+	return fmt.Sprint(v.Interface())
+}
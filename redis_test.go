@@ -0,0 +1,71 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHSetMap(t *testing.T) {
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Person struct {
+		Base
+		Name     string    `redis:"n" json:"name"`
+		Age      int       `json:"age"`
+		Admin    bool      `json:"admin"`
+		Score    float64   `json:"score"`
+		Tags     []string  `json:"tags"`
+		Birthday time.Time `json:"birthday"`
+		Address  Address   `json:"address"`
+		Secret   string    `redis:"-" json:"secret"`
+	}
+
+	tests := map[string]struct {
+		Payload  string
+		Expected map[string]any
+	}{
+		"empty": {
+			Payload:  `{}`,
+			Expected: map[string]any{},
+		},
+		"set fields": {
+			Payload: `{"name": "alice", "age": 0, "admin": true, "score": 1.5, "tags": ["a", "b"],
+				"birthday": "2000-01-02T00:00:00Z", "address": {"street": "Main St"}, "secret": "x"}`,
+			Expected: map[string]any{
+				"n":              "alice",
+				"age":            "0",
+				"admin":          "1",
+				"score":          "1.5",
+				"tags":           `["a","b"]`,
+				"birthday":       "2000-01-02T00:00:00Z",
+				"address.street": "Main St",
+			},
+		},
+		"embedded": {
+			Payload:  `{"id": "x", "name": "alice"}`,
+			Expected: map[string]any{"id": "x", "n": "alice"},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			fields := HSetMap(p)
+
+			// Assert
+			assert.Equal(t, testData.Expected, fields)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package nullify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// Violation is a single validation failure of a nullified value
+type Violation struct {
+	Path    string `json:"path"`            // json path of the field, e.g. address.street or tags[0]
+	Tag     string `json:"tag"`             // validation tag that failed, e.g. required
+	Param   string `json:"param,omitempty"` // parameter of the validation tag, e.g. 5 for min=5
+	Value   any    `json:"value,omitempty"` // dereferenced value of the field, nil if missing
+	Message string `json:"message"`         // human readable description of the failure
+	Missing bool   `json:"missing"`         // true if the field was not sent, false if it was sent but is invalid
+}
+
+// Report is the aggregated result of validating a nullified value
+type Report struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (r *Report) Error() string {
+	messages := make([]string, len(r.Violations))
+	for i, violation := range r.Violations {
+		messages[i] = violation.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Missing returns the violations of fields that were not sent
+func (r *Report) Missing() []Violation {
+	return r.filter(true)
+}
+
+// Invalid returns the violations of fields that were sent but are invalid
+func (r *Report) Invalid() []Violation {
+	return r.filter(false)
+}
+
+// filter returns the violations for which Missing equals missing
+func (r *Report) filter(missing bool) []Violation {
+	var violations []Violation
+	for _, violation := range r.Violations {
+		if violation.Missing == missing {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// ValidateAll validates the nullified value with v and collects all violations into a Report, distinguishing fields
+// that were missing from fields that were sent with an invalid value. It returns a nil Report if the value is valid
+// and an error if v cannot validate the value (e.g. because it is not a struct).
+func ValidateAll(v StructValidator, nullified any) (*Report, error) {
+	err := v.StructCtx(context.Background(), nullified)
+	if err == nil {
+		return nil, nil
+	}
+
+	errs, ok := fieldErrors(err)
+	if !ok {
+		return nil, err
+	}
+
+	report := &Report{Violations: make([]Violation, len(errs))}
+	for i, fieldError := range errs {
+		violation := Violation{
+			Path:  jsonPath(reflect.TypeOf(nullified), fieldError.StructNamespace()),
+			Tag:   fieldError.Tag(),
+			Param: fieldError.Param(),
+		}
+
+		value, ok := plain(reflect.ValueOf(fieldError.Value()))
+		switch {
+		case !ok:
+			violation.Missing = true
+			violation.Message = violation.Path + " is missing"
+		case violation.Param != "":
+			violation.Value = value
+			violation.Message = violation.Path + " must satisfy " + violation.Tag + "=" + violation.Param
+		default:
+			violation.Value = value
+			violation.Message = violation.Path + " must satisfy " + violation.Tag
+		}
+		report.Violations[i] = violation
+	}
+	return report, nil
+}
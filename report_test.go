@@ -0,0 +1,97 @@
+package nullify
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateAll(t *testing.T) {
+	type Person struct {
+		Name  string   `json:"name" validate:"required"`
+		Email string   `json:"email" validate:"required,email"`
+		Age   int      `json:"age" validate:"omitnil,min=18"`
+		Tags  []string `json:"tags" validate:"omitnil,dive,min=2"`
+	}
+
+	tests := map[string]struct {
+		Payload string
+		Report  *Report
+	}{
+		"valid": {
+			Payload: `{"name": "alice", "email": "alice@example.com"}`,
+		},
+		"missing": {
+			Payload: `{"email": "alice@example.com"}`,
+			Report: &Report{Violations: []Violation{
+				{Path: "name", Tag: "required", Message: "name is missing", Missing: true},
+			}},
+		},
+		"invalid": {
+			Payload: `{"name": "alice", "email": "invalid", "age": 17, "tags": ["a"]}`,
+			Report: &Report{Violations: []Violation{
+				{Path: "email", Tag: "email", Value: "invalid", Message: "email must satisfy email"},
+				{Path: "age", Tag: "min", Param: "18", Value: 17, Message: "age must satisfy min=18"},
+				{Path: "tags[0]", Tag: "min", Param: "2", Value: "a", Message: "tags[0] must satisfy min=2"},
+			}},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{}, JsonOptions...)
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			report, err := ValidateAll(validator.New(), p)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.Report, report)
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name  string   `json:"name" validate:"required"`
+		Email string   `json:"email" validate:"required,email"`
+		Age   int      `json:"age" validate:"omitnil,min=18"`
+		Tags  []string `json:"tags" validate:"omitnil,dive,min=2"`
+	}
+
+	p := Nullify(Person{}, JsonOptions...)
+	if err := json.Unmarshal([]byte(`{"email": "invalid"}`), p); err != nil {
+		t.Fatal(err)
+	}
+	report, _ := ValidateAll(validator.New(), p)
+
+	// Act
+	body, err := json.Marshal(report)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"violations": [
+		{"path": "name", "tag": "required", "message": "name is missing", "missing": true},
+		{"path": "email", "tag": "email", "value": "invalid", "message": "email must satisfy email", "missing": false}
+	]}`, string(body))
+	assert.Equal(t, "name is missing\nemail must satisfy email", report.Error())
+	assert.Equal(t, []Violation{report.Violations[0]}, report.Missing())
+	assert.Equal(t, []Violation{report.Violations[1]}, report.Invalid())
+}
+
+func TestValidateAll_InvalidValidation(t *testing.T) {
+	// Act
+	report, err := ValidateAll(validator.New(), "no struct")
+
+	// Assert
+	assert.Nil(t, report)
+	var invalid *validator.InvalidValidationError
+	assert.True(t, errors.As(err, &invalid))
+}
@@ -0,0 +1,72 @@
+package nullify
+
+import (
+	"reflect"
+	"slices"
+)
+
+// NullifyResult describes the nullified version of a type, such that tooling operating on nullified values does
+// not have to recompute it
+type NullifyResult struct {
+	Type     reflect.Type     // type of the instances returned by Instance, always a pointer type
+	Original reflect.Type     // type that was nullified
+	Fields   map[string][]int // indices of the (nested) struct fields by dotted path of Go field names, e.g. Address.City
+}
+
+// NewNullifyResult nullifies the type of obj like Nullify does and indexes the fields of the result. It returns nil
+// if obj is the nil interface.
+func NewNullifyResult(obj any, options ...option) *NullifyResult {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil
+	}
+
+	result := &NullifyResult{
+		Type:     nullifiedType(typeOf, options...),
+		Original: typeOf,
+		Fields:   map[string][]int{},
+	}
+	result.index(result.Type.Elem(), "", nil)
+	return result
+}
+
+// Instance returns a new zeroed instance of Type, equivalent to the result of Nullify
+func (r *NullifyResult) Instance() any {
+	countInstance(r.Original)
+	return reflect.New(r.Type.Elem()).Interface()
+}
+
+// Field returns the field at path of the instance v, false if the path is unknown or v or a struct on the path is nil
+func (r *NullifyResult) Field(v any, path string) (reflect.Value, bool) {
+	index, ok := r.Fields[path]
+	if !ok || reflect.TypeOf(v) != r.Type || reflect.ValueOf(v).IsNil() {
+		return reflect.Value{}, false
+	}
+
+	field, err := reflect.ValueOf(v).Elem().FieldByIndexErr(index)
+	return field, err == nil
+}
+
+// index adds the fields of the nullified struct t to Fields
+func (r *NullifyResult) index(t reflect.Type, path string, index []int) {
+	if !isNullifiedStruct(t) {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := joinPath(path, field.Name)
+		fieldIndex := append(slices.Clip(index), i)
+		r.Fields[fieldPath] = fieldIndex
+
+		nested := field.Type
+		if nested.Kind() == reflect.Pointer {
+			nested = nested.Elem()
+		}
+		r.index(nested, fieldPath, fieldIndex) // nested structs are values with NullifyStructFields false
+	}
+}
@@ -0,0 +1,110 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestNewNullifyResult(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name"`
+		Address Address  `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+
+	// Act
+	result := NewNullifyResult(Person{})
+
+	// Assert
+	assert.Equal(t, reflect.TypeOf(Nullify(Person{})), result.Type)
+	assert.Equal(t, reflect.TypeOf(Person{}), result.Original)
+	assert.Equal(t, map[string][]int{
+		"Name":         {0},
+		"Address":      {1},
+		"Address.City": {1, 0},
+		"Tags":         {2},
+	}, result.Fields)
+	assert.Equal(t, Nullify(Person{}), result.Instance())
+}
+
+func TestNewNullifyResult_Nil(t *testing.T) {
+	// Act & Assert
+	assert.Nil(t, NewNullifyResult(nil))
+}
+
+func TestNullifyResult_Field(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name"`
+		Address Address  `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+
+	result := NewNullifyResult(Person{})
+	set := result.Instance()
+	if err := json.Unmarshal([]byte(`{"name": "alice", "address": {"city": "Springfield"}}`), set); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		Instance any
+		Path     string
+		Expected any
+		Ok       bool
+	}{
+		"field":        {Instance: set, Path: "Name", Expected: "alice", Ok: true},
+		"nested":       {Instance: set, Path: "Address.City", Expected: "Springfield", Ok: true},
+		"nil struct":   {Instance: result.Instance(), Path: "Address.City"},
+		"unknown path": {Instance: set, Path: "Unknown"},
+		"wrong type":   {Instance: &Person{}, Path: "Name"},
+		"nil instance": {Instance: reflect.Zero(result.Type).Interface(), Path: "Name"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			field, ok := result.Field(testData.Instance, testData.Path)
+
+			// Assert
+			assert.Equal(t, testData.Ok, ok)
+			if testData.Ok {
+				assert.Equal(t, testData.Expected, field.Elem().Interface())
+			}
+		})
+	}
+}
+
+func TestNullifyResult_ValueStructFields(t *testing.T) {
+	// Arrange
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name"`
+		Address Address  `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+
+	result := NewNullifyResult(Person{}, NullifyStructFields{Value: false})
+	set := result.Instance()
+	if err := json.Unmarshal([]byte(`{"address": {"city": "Springfield"}}`), set); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	field, ok := result.Field(set, "Address.City")
+
+	// Assert
+	assert.Equal(t, []int{1, 0}, result.Fields["Address.City"])
+	assert.True(t, ok)
+	assert.Equal(t, "Springfield", field.Elem().Interface())
+}
@@ -0,0 +1,298 @@
+// Package schemagen builds nullified Go types from JSON Schema documents, such that schema-first code bases get
+// presence-aware decode targets without hand-writing Go structs.
+//
+// The supported subset of JSON Schema covers the keywords that determine the shape of a document: type,
+// properties, required, items, additionalProperties and local $ref pointers into definitions or $defs.
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Emptyless/nullify"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// schema is the subset of a JSON Schema document used to derive a Go type
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Definitions          map[string]*schema `json:"definitions"`
+	Defs                 map[string]*schema `json:"$defs"`
+}
+
+// schemaType is the JSON Schema type keyword which is either a single type or a list of types
+type schemaType []string
+
+func (s *schemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = schemaType{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("schemagen: type must be a string or an array of strings: %w", err)
+	}
+	*s = multiple
+	return nil
+}
+
+// FromJSONSchema returns the nullified Go type described by the JSON Schema doc. Objects become structs with a json
+// tag per property, arrays become slices, and every value is pointerized as if the type was passed to
+// nullify.Nullify with options. Properties listed as required receive a `validate:"required"` tag. Property names
+// that encoding/json cannot use as a tag name (e.g. containing a quote or a comma) are an error.
+//
+// For a schema of type object the returned type is the struct type, use reflect.New to obtain a decode target.
+func FromJSONSchema(doc []byte, options ...nullify.Option) (reflect.Type, error) {
+	t, err := plainType(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.TypeOf(nullify.Nullify(reflect.Zero(t).Interface(), options...)).Elem(), nil
+}
+
+// GenerateGo returns formatted Go source declaring the nullified type described by the JSON Schema doc as name in
+// package pkg. This is the codegen counterpart of FromJSONSchema.
+func GenerateGo(doc []byte, pkg string, name string, options ...nullify.Option) ([]byte, error) {
+	t, err := FromJSONSchema(doc, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by schemagen. DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkg + "\n\n")
+	b.WriteString("type " + name + " ")
+	writeType(&b, t)
+	b.WriteString("\n")
+
+	return format.Source([]byte(b.String()))
+}
+
+// plainType returns the non-nullified Go type described by the JSON Schema doc
+func plainType(doc []byte) (reflect.Type, error) {
+	var root schema
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("schemagen: invalid schema: %w", err)
+	}
+
+	b := builder{root: &root, resolving: map[string]bool{}}
+	return b.build(&root, "#")
+}
+
+// builder converts schemas into types, resolving references against the root document
+type builder struct {
+	root      *schema
+	resolving map[string]bool
+}
+
+// build returns the Go type for s, path is the location of s in the document used in errors
+func (b builder) build(s *schema, path string) (reflect.Type, error) {
+	if s.Ref != "" {
+		return b.ref(s.Ref)
+	}
+
+	switch s.primaryType() {
+	case "object":
+		return b.object(s, path)
+	case "array":
+		if s.Items == nil {
+			return reflect.TypeOf([]any{}), nil
+		}
+		elem, err := b.build(s.Items, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+	case "string":
+		return reflect.TypeOf(""), nil
+	case "integer":
+		return reflect.TypeOf(int64(0)), nil
+	case "number":
+		return reflect.TypeOf(float64(0)), nil
+	case "boolean":
+		return reflect.TypeOf(false), nil
+	case "":
+		return reflect.TypeOf((*any)(nil)).Elem(), nil
+	default:
+		return nil, fmt.Errorf("schemagen: %s: unsupported type %q", path, s.primaryType())
+	}
+}
+
+// object returns a struct type for an object schema with properties, or a map type otherwise
+func (b builder) object(s *schema, path string) (reflect.Type, error) {
+	if len(s.Properties) == 0 {
+		var additional schema
+		if len(s.AdditionalProperties) > 0 && string(s.AdditionalProperties) != "true" && string(s.AdditionalProperties) != "false" {
+			if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+				return nil, fmt.Errorf("schemagen: %s: invalid additionalProperties: %w", path, err)
+			}
+		}
+		elem, err := b.build(&additional, path+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(reflect.TypeOf(""), elem), nil
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	used := make(map[string]bool, len(names))
+	fields := make([]reflect.StructField, 0, len(names))
+	for _, name := range names {
+		if !isValidTag(name) {
+			return nil, fmt.Errorf("schemagen: %s: property %q cannot be used as a json tag name", path, name)
+		}
+		t, err := b.build(s.Properties[name], path+"/properties/"+name)
+		if err != nil {
+			return nil, err
+		}
+
+		tagName := name
+		if name == "-" {
+			tagName = "-," // a bare - would leave the field out
+		}
+		tag := `json:` + strconv.Quote(tagName)
+		if required[name] {
+			tag += ` validate:"required"`
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: fieldName(name, used),
+			Type: t,
+			Tag:  reflect.StructTag(tag),
+		})
+	}
+
+	return reflect.StructOf(fields), nil
+}
+
+// ref resolves a local reference (e.g. #/definitions/Address or #/$defs/Address)
+func (b builder) ref(ref string) (reflect.Type, error) {
+	if b.resolving[ref] {
+		return nil, fmt.Errorf("schemagen: recursive reference %q is not supported", ref)
+	}
+
+	var target *schema
+	switch {
+	case strings.HasPrefix(ref, "#/definitions/"):
+		target = b.root.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+	case strings.HasPrefix(ref, "#/$defs/"):
+		target = b.root.Defs[strings.TrimPrefix(ref, "#/$defs/")]
+	}
+	if target == nil {
+		return nil, fmt.Errorf("schemagen: unresolvable reference %q", ref)
+	}
+
+	b.resolving[ref] = true
+	defer delete(b.resolving, ref)
+	return b.build(target, ref)
+}
+
+// isValidTag returns true if encoding/json accepts name as the name in a json tag
+func isValidTag(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// primaryType returns the first non-null type of the schema
+func (s *schema) primaryType() string {
+	for _, t := range s.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// fieldName converts a property name to a unique exported Go identifier, e.g. first_name becomes FirstName
+func fieldName(property string, used map[string]bool) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range property {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = "X" + name
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// writeType writes the Go source representation of t
+func writeType(b *strings.Builder, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		b.WriteString("*")
+		writeType(b, t.Elem())
+	case reflect.Slice:
+		b.WriteString("[]")
+		writeType(b, t.Elem())
+	case reflect.Map:
+		b.WriteString("map[")
+		writeType(b, t.Key())
+		b.WriteString("]")
+		writeType(b, t.Elem())
+	case reflect.Interface:
+		b.WriteString("any")
+	case reflect.Struct:
+		b.WriteString("struct {\n")
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			b.WriteString(field.Name + " ")
+			writeType(b, field.Type)
+			if field.Tag != "" {
+				b.WriteString(" `" + string(field.Tag) + "`")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(t.String())
+	}
+}
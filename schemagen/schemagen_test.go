@@ -0,0 +1,173 @@
+package schemagen
+
+import (
+	"encoding/json"
+	"github.com/Emptyless/nullify"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+const personSchema = `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string"},
+		"age": {"type": ["integer", "null"]},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"address": {"$ref": "#/$defs/Address"},
+		"labels": {"type": "object", "additionalProperties": {"type": "boolean"}}
+	},
+	"$defs": {
+		"Address": {
+			"type": "object",
+			"properties": {
+				"street_name": {"type": "string"},
+				"number": {"type": "number"}
+			}
+		}
+	}
+}`
+
+func TestFromJSONSchema(t *testing.T) {
+	// Act
+	typ, err := FromJSONSchema([]byte(personSchema))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.Struct, typ.Kind())
+
+	name, ok := typ.FieldByName("Name")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), name.Type)
+	assert.Equal(t, reflect.StructTag(`json:"name" validate:"required"`), name.Tag)
+
+	age, ok := typ.FieldByName("Age")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf((*int64)(nil)), age.Type)
+
+	tags, ok := typ.FieldByName("Tags")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf((*[]*string)(nil)), tags.Type)
+
+	labels, ok := typ.FieldByName("Labels")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf((*map[*string]*bool)(nil)), labels.Type)
+
+	address, ok := typ.FieldByName("Address")
+	assert.True(t, ok)
+	street, ok := address.Type.Elem().FieldByName("StreetName")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.StructTag(`json:"street_name"`), street.Tag)
+}
+
+func TestFromJSONSchema_Options(t *testing.T) {
+	// Act
+	typ, err := FromJSONSchema([]byte(personSchema), nullify.BareContainers{Value: true})
+
+	// Assert
+	assert.Nil(t, err)
+	tags, ok := typ.FieldByName("Tags")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf([]*string{}), tags.Type)
+}
+
+func TestFromJSONSchema_PropertyNames(t *testing.T) {
+	// Arrange
+	doc := `{"type": "object", "properties": {"-": {"type": "string"}, "a b": {"type": "string"}}}`
+
+	// Act
+	typ, err := FromJSONSchema([]byte(doc))
+
+	// Assert
+	assert.Nil(t, err)
+	v := reflect.New(typ)
+	assert.Nil(t, json.Unmarshal([]byte(`{"-": "dash", "a b": "space"}`), v.Interface()))
+	assert.Equal(t, "dash", v.Elem().Field(0).Elem().Interface())
+	assert.Equal(t, "space", v.Elem().Field(1).Elem().Interface())
+}
+
+func TestFromJSONSchema_UnexportableNames(t *testing.T) {
+	// Arrange
+	doc := `{"type": "object", "properties": {"名前": {"type": "string"}, "1st": {"type": "string"}}}`
+
+	// Act
+	typ, err := FromJSONSchema([]byte(doc))
+
+	// Assert
+	assert.Nil(t, err)
+	v := reflect.New(typ)
+	assert.Nil(t, json.Unmarshal([]byte(`{"名前": "name", "1st": "first"}`), v.Interface()))
+	name, ok := typ.FieldByName("X名前")
+	assert.True(t, ok)
+	assert.Equal(t, "name", v.Elem().FieldByIndex(name.Index).Elem().Interface())
+	first, ok := typ.FieldByName("X1st")
+	assert.True(t, ok)
+	assert.Equal(t, "first", v.Elem().FieldByIndex(first.Index).Elem().Interface())
+}
+
+func TestFromJSONSchema_Decode(t *testing.T) {
+	// Arrange
+	typ, err := FromJSONSchema([]byte(personSchema))
+	assert.Nil(t, err)
+	v := reflect.New(typ)
+
+	// Act
+	err = json.Unmarshal([]byte(`{"name": "alice", "address": {"number": 12}}`), v.Interface())
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", v.Elem().FieldByName("Name").Elem().Interface())
+	assert.True(t, v.Elem().FieldByName("Age").IsNil())
+	assert.Equal(t, float64(12), v.Elem().FieldByName("Address").Elem().FieldByName("Number").Elem().Interface())
+}
+
+func TestFromJSONSchema_Errors(t *testing.T) {
+	tests := map[string]struct {
+		Schema       string
+		ErrorMessage string
+	}{
+		"invalid json":     {Schema: `{`, ErrorMessage: "schemagen: invalid schema: unexpected end of JSON input"},
+		"unsupported type": {Schema: `{"type": "date"}`, ErrorMessage: `schemagen: #: unsupported type "date"`},
+		"unresolvable ref": {Schema: `{"$ref": "#/$defs/Missing"}`, ErrorMessage: `schemagen: unresolvable reference "#/$defs/Missing"`},
+		"invalid property name": {
+			Schema:       `{"properties": {"a\"b": {"type": "string"}}}`,
+			ErrorMessage: `schemagen: #: property "a\"b" cannot be used as a json tag name`,
+		},
+		"empty property name": {
+			Schema:       `{"properties": {"": {"type": "string"}}}`,
+			ErrorMessage: `schemagen: #: property "" cannot be used as a json tag name`,
+		},
+		"recursive ref": {
+			Schema:       `{"$ref": "#/$defs/Node", "$defs": {"Node": {"properties": {"next": {"$ref": "#/$defs/Node"}}}}}`,
+			ErrorMessage: `schemagen: recursive reference "#/$defs/Node" is not supported`,
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := FromJSONSchema([]byte(testData.Schema))
+
+			// Assert
+			assert.EqualError(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	// Arrange
+	doc := `{"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "count": {"type": "integer"}}}`
+
+	// Act
+	src, err := GenerateGo([]byte(doc), "api", "Patch")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "// Code generated by schemagen. DO NOT EDIT.\n\n"+
+		"package api\n\n"+
+		"type Patch struct {\n"+
+		"\tCount *int64  `json:\"count\"`\n"+
+		"\tId    *string `json:\"id\" validate:\"required\"`\n"+
+		"}\n", string(src))
+}
@@ -0,0 +1,22 @@
+//go:build go1.23
+
+package nullify
+
+import (
+	"iter"
+	"reflect"
+)
+
+// SetFields returns an iterator over the fields of the nullified value that are set (non-nil), yielding the dotted
+// json path and dereferenced value of each in field order, i.e. the entries of Flatten without building a map:
+//
+//	for path, value := range nullify.SetFields(p) {
+//		...
+//	}
+func SetFields(nullified any, options ...option) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		if v, ok := indirect(reflect.ValueOf(nullified)); ok && isNullifiedStruct(v.Type()) {
+			flatten(v, "", newConfig(options...), yield)
+		}
+	}
+}
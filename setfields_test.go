@@ -0,0 +1,106 @@
+//go:build go1.23
+
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetFields(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Secret  string   `json:"-"`
+	}
+
+	p := Nullify(Person{})
+	payload := `{"id": "1", "name": "", "tags": ["a"], "address": {"city": "Springfield", "street": "Main St"}}`
+	if err := json.Unmarshal([]byte(payload), p); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	values := map[string]any{}
+
+	// Act
+	for path, value := range SetFields(p) {
+		paths = append(paths, path)
+		values[path] = value
+	}
+
+	// Assert
+	assert.Equal(t, []string{"id", "name", "tags", "address.street", "address.city"}, paths)
+	assert.Equal(t, Flatten(p), values)
+}
+
+func TestSetFields_Break(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Secret  string   `json:"-"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"id": "1", "name": "alice", "address": {"city": "Springfield"}}`), p); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+
+	// Act
+	for path := range SetFields(p) {
+		paths = append(paths, path)
+		if path == "name" {
+			break
+		}
+	}
+
+	// Assert
+	assert.Equal(t, []string{"id", "name"}, paths)
+}
+
+func TestSetFields_NotNullified(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Secret  string   `json:"-"`
+	}
+
+	// Act & Assert
+	for range SetFields(&Person{}) {
+		t.Error("unexpected field")
+	}
+	for range SetFields(nil) {
+		t.Error("unexpected field")
+	}
+}
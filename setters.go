@@ -0,0 +1,48 @@
+package nullify
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplySetters calls the setter method of target for every field of the nullified value that is set (non-nil),
+// e.g. target.SetName(name) for the field Name, such that only provided fields are applied. This matches the
+// builders and mutations generated by ent (e.g. *ent.UserUpdateOne) for the entity struct that was nullified:
+//
+//	patch := nullify.Nullify(ent.User{})
+//	_ = json.Unmarshal(body, patch)
+//	err := nullify.ApplySetters(patch, client.User.UpdateOneID(id))
+//
+// Nested structs (e.g. edges) are skipped. Values are converted to the parameter type of the setter where possible.
+// It returns an error if target has no setter for a set field.
+func ApplySetters(nullified any, target any) error {
+	v, ok := indirect(reflect.ValueOf(nullified))
+	if !ok || !isNullifiedStruct(v.Type()) {
+		return fmt.Errorf("nullify: nullified must be a pointer to a nullified struct, got %T", nullified)
+	}
+
+	t := reflect.ValueOf(target)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value, ok := indirect(v.Field(i))
+		if !ok || !field.IsExported() || isNullifiedStruct(value.Type()) {
+			continue
+		}
+
+		setter := t.MethodByName("Set" + field.Name)
+		if !setter.IsValid() || setter.Type().NumIn() != 1 {
+			return fmt.Errorf("nullify: %s: %T has no method Set%s", field.Name, target, field.Name)
+		}
+
+		param := setter.Type().In(0)
+		switch {
+		case value.Type().AssignableTo(param):
+		case value.Type().ConvertibleTo(param):
+			value = value.Convert(param)
+		default:
+			return fmt.Errorf("nullify: %s: cannot pass %s to Set%s(%s)", field.Name, value.Type(), field.Name, param)
+		}
+		setter.Call([]reflect.Value{value})
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// settersMutation mimics the update builders generated by ent
+type settersMutation struct {
+	calls []string
+}
+
+func (m *settersMutation) SetName(s string) *settersMutation {
+	m.calls = append(m.calls, "SetName("+s+")")
+	return m
+}
+
+func (m *settersMutation) SetAge(i int) *settersMutation {
+	m.calls = append(m.calls, "SetAge")
+	return m
+}
+
+func (m *settersMutation) SetStatus(s string) *settersMutation {
+	m.calls = append(m.calls, "SetStatus("+s+")")
+	return m
+}
+
+func TestApplySetters(t *testing.T) {
+	type Status string
+	type User struct {
+		Name      string    `json:"name,omitempty"`
+		Age       int       `json:"age,omitempty"`
+		Status    Status    `json:"status,omitempty"`
+		UpdatedAt time.Time `json:"updated_at,omitempty"`
+		Edges     struct {
+			Friends []string `json:"friends,omitempty"`
+		} `json:"edges"`
+	}
+
+	tests := map[string]struct {
+		Payload      string
+		Calls        []string
+		ErrorMessage string
+	}{
+		"set fields": {
+			Payload: `{"name": "alice", "status": "active", "edges": {"friends": ["bob"]}}`,
+			Calls:   []string{"SetName(alice)", "SetStatus(active)"},
+		},
+		"none": {
+			Payload: `{}`,
+		},
+		"missing setter": {
+			Payload:      `{"age": 3, "updated_at": "2024-01-01T00:00:00Z"}`,
+			Calls:        []string{"SetAge"},
+			ErrorMessage: "nullify: UpdatedAt: *nullify.settersMutation has no method SetUpdatedAt",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(User{})
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+			m := &settersMutation{}
+
+			// Act
+			err := ApplySetters(p, m)
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, testData.ErrorMessage)
+			}
+			assert.Equal(t, testData.Calls, m.calls)
+		})
+	}
+}
+
+func TestApplySetters_NotNullified(t *testing.T) {
+	// Arrange
+	type Status string
+	type User struct {
+		Name      string    `json:"name,omitempty"`
+		Age       int       `json:"age,omitempty"`
+		Status    Status    `json:"status,omitempty"`
+		UpdatedAt time.Time `json:"updated_at,omitempty"`
+		Edges     struct {
+			Friends []string `json:"friends,omitempty"`
+		} `json:"edges"`
+	}
+
+	// Act
+	err := ApplySetters(User{}, &settersMutation{})
+
+	// Assert
+	assert.EqualError(t, err, "nullify: nullified must be a pointer to a nullified struct, got nullify.User")
+}
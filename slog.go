@@ -0,0 +1,46 @@
+package nullify
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// LogValue wraps a nullified value in a slog.LogValuer that logs only the set fields as structured attributes,
+// keyed by Go field name, with nested structs as groups and pointers dereferenced. Unset fields are omitted.
+//
+//	slog.Info("patch received", "patch", nullify.LogValue(p))
+func LogValue(nullified any) slog.LogValuer {
+	return logValuer{value: nullified}
+}
+
+// logValuer implements slog.LogValuer for a nullified value
+type logValuer struct {
+	value any
+}
+
+func (l logValuer) LogValue() slog.Value {
+	return logValue(reflect.ValueOf(l.value))
+}
+
+// logValue returns the slog.Value of v, nullified structs become groups of their set fields
+func logValue(v reflect.Value) slog.Value {
+	v, ok := indirect(v)
+	if !ok {
+		return slog.AnyValue(nil)
+	}
+
+	if !isNullifiedStruct(v.Type()) {
+		value, _ := plain(v)
+		return slog.AnyValue(value)
+	}
+
+	attrs := make([]slog.Attr, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if _, ok := indirect(v.Field(i)); !ok || !field.IsExported() {
+			continue
+		}
+		attrs = append(attrs, slog.Attr{Key: field.Name, Value: logValue(v.Field(i))})
+	}
+	return slog.GroupValue(attrs...)
+}
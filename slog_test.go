@@ -0,0 +1,52 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string
+		City   string
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Tags    []string
+		Address Address
+	}
+
+	p := Nullify(Person{})
+	err := json.Unmarshal([]byte(`{"Name": "bob", "Tags": ["a"], "Address": {"City": "Springfield"}}`), p)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	// Act
+	logger.Info("patch", "patch", LogValue(p))
+
+	// Assert
+	assert.Equal(t, "msg=patch patch.Name=bob patch.Tags=[a] patch.Address.City=Springfield\n", buf.String())
+}
+
+func TestLogValue_Unset(t *testing.T) {
+	// Arrange
+	name := "a"
+
+	// Assert
+	assert.Equal(t, slog.AnyValue(nil), LogValue(nil).LogValue())
+	assert.Equal(t, "a", LogValue(&name).LogValue().String())
+}
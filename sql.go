@@ -0,0 +1,68 @@
+package nullify
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Placeholder returns the bind parameter for the n-th (1-based) argument of a query
+type Placeholder func(n int) string
+
+// Dollar placeholders as used by PostgreSQL drivers such as pgx and lib/pq: $1, $2, ...
+var Dollar Placeholder = func(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// Question placeholders as used by MySQL and SQLite drivers: ?, ?, ...
+var Question Placeholder = func(int) string {
+	return "?"
+}
+
+// SetClause returns the `col1 = $1, col2 = $2` fragment of an SQL UPDATE statement for the fields of the
+// nullified value that are set (non-nil), together with the dereferenced values as arguments. Numbering starts
+// at 1, such that a WHERE clause can continue at len(args)+1.
+//
+// The column name is taken from the `db` tag or the snake_case field name, unless WithTagPriority names the field.
+// Fields tagged `db:"-"` are skipped, embedded structs are flattened and other nested structs are skipped. Column
+// names are not quoted and must come from trusted struct tags.
+func SetClause(nullified any, placeholder Placeholder, options ...option) (string, []any) {
+	var assignments []string
+	var args []any
+	if v, ok := indirect(reflect.ValueOf(nullified)); ok && v.Kind() == reflect.Struct {
+		setClause(v, placeholder, newConfig(options...), &assignments, &args)
+	}
+	return strings.Join(assignments, ", "), args
+}
+
+// setClause appends an assignment and argument for each set field of the struct value v
+func setClause(v reflect.Value, placeholder Placeholder, cfg config, assignments *[]string, args *[]any) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		column, found := priorityName(field, cfg)
+		if !found {
+			column, _, _ = strings.Cut(field.Tag.Get("db"), ",")
+		}
+		if column == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, ok := present(v.Field(i), cfg)
+		if !ok {
+			continue
+		}
+
+		if isNullifiedStruct(value.Type()) {
+			if field.Anonymous {
+				setClause(value, placeholder, cfg, assignments, args)
+			}
+			continue
+		}
+
+		if column == "" {
+			column = snakeCase(field.Name)
+		}
+		*args = append(*args, value.Interface())
+		*assignments = append(*assignments, column+" = "+placeholder(len(*args)))
+	}
+}
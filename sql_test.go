@@ -0,0 +1,75 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetClause(t *testing.T) {
+	// Arrange
+	type Audit struct {
+		UpdatedBy string `db:"updated_by"`
+	}
+	type Address struct {
+		Street string `db:"street"`
+	}
+	type User struct {
+		Audit
+		ID       string  `db:"id"`
+		Name     string  `db:"name"`
+		Email    string  `db:"email_address"`
+		Internal string  `db:"-"`
+		Age      int     `json:"age"`
+		Address  Address `db:"address"`
+	}
+
+	patch := Nullify(User{})
+	err := json.Unmarshal([]byte(`{"UpdatedBy": "admin", "Name": "alice", "Internal": "x", "age": 30, "Address": {"Street": "Main St"}}`), patch)
+	assert.Nil(t, err)
+
+	tests := map[string]struct {
+		Placeholder Placeholder
+		Clause      string
+	}{
+		"Dollar":   {Placeholder: Dollar, Clause: "updated_by = $1, name = $2, age = $3"},
+		"Question": {Placeholder: Question, Clause: "updated_by = ?, name = ?, age = ?"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			clause, args := SetClause(patch, testData.Placeholder)
+
+			// Assert
+			assert.Equal(t, testData.Clause, clause)
+			assert.Equal(t, []any{"admin", "alice", 30}, args)
+		})
+	}
+}
+
+func TestSetClause_BareContainers(t *testing.T) {
+	// Arrange
+	type User struct {
+		Name string   `db:"name"`
+		Tags []string `db:"tags"`
+	}
+	patch := Nullify(User{}, BareContainers{Value: true})
+	assert.Nil(t, json.Unmarshal([]byte(`{"Name": "alice"}`), patch))
+
+	// Act
+	clause, args := SetClause(patch, Dollar, BareContainers{Value: true})
+
+	// Assert
+	assert.Equal(t, "name = $1", clause)
+	assert.Equal(t, []any{"alice"}, args)
+}
+
+func TestSetClause_Empty(t *testing.T) {
+	// Act
+	clause, args := SetClause(Nullify(struct{ Name string }{}), Dollar)
+
+	// Assert
+	assert.Equal(t, "", clause)
+	assert.Nil(t, args)
+}
@@ -0,0 +1,88 @@
+package nullify
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statistics is a snapshot of the counters of the type cache and of the instances created by Nullify, e.g. to export
+// them as OpenTelemetry metrics. Counters only ever increase.
+type Statistics struct {
+	CacheHits   uint64                  // nullified types served from the cache
+	CacheMisses uint64                  // nullified types built and stored in the cache
+	Uncached    uint64                  // nullified types built for options that cannot be cached
+	CompileTime time.Duration           // total time spent building nullified types
+	Instances   map[reflect.Type]uint64 // instances created by Nullify and NullifyResult.Instance by original type
+}
+
+// CompileEvent describes the build of a nullified type, see OnCompile
+type CompileEvent struct {
+	Original reflect.Type  // type that was nullified
+	Type     reflect.Type  // nullified type, always a pointer type
+	Duration time.Duration // time spent building the nullified type
+	Cached   bool          // false if the options cannot be cached, see Compile
+}
+
+// stats holds the counters reported by Stats
+var stats struct {
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+	uncached    atomic.Uint64
+	compileTime atomic.Int64
+	instances   sync.Map // reflect.Type -> *atomic.Uint64
+}
+
+// compileHook is the function registered with OnCompile
+var compileHook atomic.Pointer[func(CompileEvent)]
+
+// Stats returns a snapshot of the counters of the type cache and of the instances created per type
+func Stats() Statistics {
+	snapshot := Statistics{
+		CacheHits:   stats.cacheHits.Load(),
+		CacheMisses: stats.cacheMisses.Load(),
+		Uncached:    stats.uncached.Load(),
+		CompileTime: time.Duration(stats.compileTime.Load()),
+		Instances:   map[reflect.Type]uint64{},
+	}
+	stats.instances.Range(func(key, value any) bool {
+		snapshot.Instances[key.(reflect.Type)] = value.(*atomic.Uint64).Load()
+		return true
+	})
+	return snapshot
+}
+
+// OnCompile registers fn to be called synchronously every time a nullified type is built rather than served from
+// the cache, e.g. to record compile durations in a histogram. Passing nil removes the hook. fn must be safe for
+// concurrent use as types may be built from multiple goroutines.
+func OnCompile(fn func(CompileEvent)) {
+	if fn == nil {
+		compileHook.Store(nil)
+		return
+	}
+	compileHook.Store(&fn)
+}
+
+// recordCompile updates the counters for a nullified type that was built and calls the OnCompile hook
+func recordCompile(event CompileEvent) {
+	if event.Cached {
+		stats.cacheMisses.Add(1)
+	} else {
+		stats.uncached.Add(1)
+	}
+	stats.compileTime.Add(int64(event.Duration))
+
+	if fn := compileHook.Load(); fn != nil {
+		(*fn)(event)
+	}
+}
+
+// countInstance increments the number of instances created for the original type t
+func countInstance(t reflect.Type) {
+	counter, ok := stats.instances.Load(t)
+	if !ok {
+		counter, _ = stats.instances.LoadOrStore(t, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+}
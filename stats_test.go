@@ -0,0 +1,50 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string
+	}
+	before := Stats()
+
+	// Act
+	Nullify(Person{})
+	Nullify(Person{})
+	NewNullifyResult(Person{}).Instance()
+	Nullify(Person{}, WithFieldTransform(func(field reflect.StructField) reflect.StructField { return field }))
+
+	// Assert
+	after := Stats()
+	assert.Equal(t, before.CacheMisses+1, after.CacheMisses)
+	assert.Equal(t, before.CacheHits+2, after.CacheHits)
+	assert.Equal(t, before.Uncached+1, after.Uncached)
+	assert.GreaterOrEqual(t, after.CompileTime, before.CompileTime)
+	assert.Equal(t, uint64(4), after.Instances[reflect.TypeOf(Person{})])
+}
+
+func TestOnCompile(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string
+	}
+	var events []CompileEvent
+	OnCompile(func(event CompileEvent) { events = append(events, event) })
+	t.Cleanup(func() { OnCompile(nil) })
+
+	// Act
+	Nullify(Person{})
+	Nullify(Person{})
+
+	// Assert
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, reflect.TypeOf(Person{}), events[0].Original)
+		assert.Equal(t, reflect.TypeOf(&struct{ Name *string }{}), events[0].Type)
+		assert.True(t, events[0].Cached)
+	}
+}
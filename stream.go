@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package nullify
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+)
+
+// Stream reads newline-delimited JSON from r, decoding each line into a new instance of the nullified type of
+// prototype and validating it with v, without buffering the whole input. The nullified type is computed once and
+// shared by all lines. Blank lines are skipped.
+//
+// Each line yields a Result like ValidateBatch does, with the decode error or ValidationErrors in Result.Err. A
+// non-nil error is yielded only if reading from r fails, after which the sequence ends.
+func Stream(r io.Reader, prototype any, v StructValidator, options ...option) iter.Seq2[Result, error] {
+	return func(yield func(Result, error) bool) {
+		instance := newNullified(prototype, options...)
+		if instance == nil {
+			return
+		}
+		typ := reflect.TypeOf(instance).Elem()
+
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				if !yield(validatePayload(line, typ, v), nil) {
+					return
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(Result{}, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+//go:build go1.23
+
+package nullify
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStream(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	input := `{"id": "1", "kind": "created"}
+
+{"kind": "created"}
+{"id": "3", "kind": "updated"}
+{
+{"id": "5"}`
+
+	// Act
+	var results []Result
+	for result, err := range Stream(strings.NewReader(input), Event{}, validator.New()) {
+		assert.NoError(t, err)
+		results = append(results, result)
+	}
+
+	// Assert
+	assert.Len(t, results, 5)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "1", *reflect.ValueOf(results[0].Presence).Elem().Field(0).Interface().(*string))
+	assert.Equal(t, ValidationErrors{{Path: "id", Tag: "required"}}, results[1].Err)
+	assert.Equal(t, ValidationErrors{{Path: "kind", Tag: "oneof", Param: "created deleted"}}, results[2].Err)
+	assert.Error(t, results[3].Err)
+	assert.Nil(t, results[4].Err)
+	assert.Equal(t, "5", *reflect.ValueOf(results[4].Presence).Elem().Field(0).Interface().(*string))
+}
+
+func TestStream_ValueResult(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	r := strings.NewReader(`{"id": "1"}`)
+
+	// Act
+	var results []Result
+	for result, err := range Stream(r, Event{}, validator.New(), ValueResult{Value: true}) {
+		assert.NoError(t, err)
+		results = append(results, result)
+	}
+
+	// Assert
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "1", *reflect.ValueOf(results[0].Presence).Elem().Field(0).Interface().(*string))
+}
+
+func TestStream_Break(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	input := "{\"id\": \"1\"}\n{\"id\": \"2\"}\n"
+
+	// Act
+	count := 0
+	for range Stream(strings.NewReader(input), Event{}, validator.New()) {
+		count++
+		break
+	}
+
+	// Assert
+	assert.Equal(t, 1, count)
+}
+
+func TestStream_ReadError(t *testing.T) {
+	// Arrange
+	type Event struct {
+		ID   string `json:"id" validate:"required"`
+		Kind string `json:"kind" validate:"omitnil,oneof=created deleted"`
+	}
+
+	readErr := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("{\"id\": \"1\"}\n"), iotest.ErrReader(readErr))
+
+	// Act
+	var errs []error
+	count := 0
+	for _, err := range Stream(r, Event{}, validator.New()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count++
+	}
+
+	// Assert
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []error{readErr}, errs)
+}
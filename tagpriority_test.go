@@ -0,0 +1,53 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithTagPriority(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string `json:"name" yaml:"full_name" db:"person_name" bson:"n"`
+		Email    string `json:"email_address" db:"email"`
+		Internal string `json:"internal" yaml:"-"`
+		Age      int
+	}
+
+	decoded, err := DecodeMap(map[string]any{"Name": "alice", "Email": "a@example.com", "Internal": "x", "Age": 30}, Person{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	option := WithTagPriority("yaml", "db")
+
+	// Act & Assert
+	assert.Equal(t, map[string]any{"full_name": nil, "email": nil, "Age": nil}, NullifyToMap(Person{}, option))
+	assert.Equal(t, map[string]any{"full_name": "alice", "email": "a@example.com", "age": 30}, UpdateMap(decoded, option))
+	assert.Equal(t, map[string]any{"full_name": "alice", "email": "a@example.com", "age": 30}, BsonSet(decoded, option))
+	clause, args := SetClause(decoded, Dollar, option)
+	assert.Equal(t, "full_name = $1, email = $2, age = $3", clause)
+	assert.Equal(t, []any{"alice", "a@example.com", 30}, args)
+
+	var person Person
+	assert.Nil(t, CopyMatching(decoded, &person, option))
+	assert.Equal(t, Person{Name: "alice", Email: "a@example.com", Age: 30}, person)
+}
+
+func TestWithTagPriority_Lazy(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name     string `json:"name" yaml:"full_name" db:"person_name" bson:"n"`
+		Email    string `json:"email_address" db:"email"`
+		Internal string `json:"internal" yaml:"-"`
+		Age      int
+	}
+
+	// Act
+	lazy, err := DecodeLazy([]byte(`{"full_name": "alice"}`), Person{}, WithTagPriority("yaml"))
+
+	// Assert
+	assert.Nil(t, err)
+	name, err := lazy.Field("full_name")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", *name.(*string))
+}
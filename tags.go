@@ -0,0 +1,262 @@
+package nullify
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// tagPair is a single `key:"value"` entry of a reflect.StructTag
+type tagPair struct {
+	key   string
+	value string
+}
+
+// parseTag splits a reflect.StructTag into its ordered key/value pairs following the conventional format
+// described by reflect.StructTag. Parsing stops at the first malformed entry.
+func parseTag(tag reflect.StructTag) []tagPair {
+	var pairs []tagPair
+	s := string(tag)
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		if s == "" {
+			break
+		}
+
+		i := 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+		key := s[:i]
+		s = s[i+1:]
+
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			break
+		}
+		s = s[i+1:]
+
+		pairs = append(pairs, tagPair{key: key, value: value})
+	}
+	return pairs
+}
+
+// formatTag joins key/value pairs back into a reflect.StructTag
+func formatTag(pairs []tagPair) reflect.StructTag {
+	parts := make([]string, len(pairs))
+	for i, pair := range pairs {
+		parts[i] = pair.key + ":" + strconv.Quote(pair.value)
+	}
+	return reflect.StructTag(strings.Join(parts, " "))
+}
+
+// setTag sets the value of key in tag, appending the key if it is not present yet
+func setTag(tag reflect.StructTag, key string, value string) reflect.StructTag {
+	pairs := parseTag(tag)
+	for i := range pairs {
+		if pairs[i].key == key {
+			pairs[i].value = value
+			return formatTag(pairs)
+		}
+	}
+	return formatTag(append(pairs, tagPair{key: key, value: value}))
+}
+
+// addTagOption appends option (e.g. omitempty) to the comma separated value of key unless it is already present.
+// Tags ignoring the field (`json:"-"`) are returned as is.
+func addTagOption(tag reflect.StructTag, key string, option string) reflect.StructTag {
+	value, _ := tag.Lookup(key)
+	if value == "-" {
+		return tag
+	}
+
+	name, opts, _ := strings.Cut(value, ",")
+	if hasOption(opts, option) {
+		return tag
+	}
+
+	if opts == "" {
+		return setTag(tag, key, name+","+option)
+	}
+	return setTag(tag, key, name+","+opts+","+option)
+}
+
+// priorityName returns the name of field from the first tag of cfg.tagPriority that names it, which is "-" if the
+// field is skipped. found is false if none of the tags names the field.
+func priorityName(field reflect.StructField, cfg config) (name string, found bool) {
+	for _, key := range cfg.tagPriority {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// fieldName returns the name of field according to cfg.tagPriority, falling back to the json name. It returns false
+// if the field is skipped.
+func fieldName(field reflect.StructField, cfg config) (string, bool) {
+	if name, found := priorityName(field, cfg); found {
+		return name, name != "-"
+	}
+	return jsonName(field)
+}
+
+// setJsonName replaces the name in the json tag, keeping its options. Tags ignoring the field (`json:"-"`) are
+// returned as is.
+func setJsonName(tag reflect.StructTag, name string) reflect.StructTag {
+	value, _ := tag.Lookup("json")
+	if value == "-" {
+		return tag
+	}
+
+	if _, opts, ok := strings.Cut(value, ","); ok {
+		return setTag(tag, "json", name+","+opts)
+	}
+	return setTag(tag, "json", name)
+}
+
+// hasOption returns true if the comma separated tag options contain option
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonName returns the name used by encoding/json for field, false if the field is ignored (`json:"-"`)
+func jsonName(field reflect.StructField) (string, bool) {
+	value := field.Tag.Get("json")
+	if value == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return field.Name, true
+	}
+	return name, true
+}
+
+// removeTag removes key from tag
+func removeTag(tag reflect.StructTag, key string) reflect.StructTag {
+	pairs := parseTag(tag)
+	return formatTag(slices.DeleteFunc(pairs, func(pair tagPair) bool {
+		return pair.key == key
+	}))
+}
+
+// removeTagOption removes option (e.g. string) from the comma separated value of key
+func removeTagOption(tag reflect.StructTag, key string, option string) reflect.StructTag {
+	value, ok := tag.Lookup(key)
+	if !ok {
+		return tag
+	}
+
+	name, opts, _ := strings.Cut(value, ",")
+	if !hasOption(opts, option) {
+		return tag
+	}
+	kept := slices.DeleteFunc(strings.Split(opts, ","), func(opt string) bool { return opt == option })
+	return setTag(tag, key, strings.Join(append([]string{name}, kept...), ","))
+}
+
+// isQuotableField returns true if encoding/json applies the `,string` option to a field of type t, i.e. a boolean,
+// number or string or an unnamed pointer to one
+func isQuotableField(t reflect.Type) bool {
+	if t.Name() == "" && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return isQuotable(t.Kind())
+}
+
+// rewriteTag returns the tag of the nullified field according to the tag related options in cfg, original is the
+// type of the field before nullification
+func rewriteTag(field reflect.StructField, original reflect.Type, cfg config) reflect.StructTag {
+	tag := field.Tag
+	for _, key := range cfg.stripTags {
+		if _, ok := tag.Lookup(key); ok {
+			tag = removeTag(tag, key)
+		}
+	}
+
+	for _, remap := range cfg.tagRemaps {
+		if value, ok := tag.Lookup(remap.Key); ok {
+			tag = setTag(tag, remap.Key, remap.Value(value))
+		}
+	}
+
+	if name, ok := protoJsonName(tag); cfg.protobuf && ok {
+		tag = setJsonName(tag, name)
+	}
+
+	if _, ok := tag.Lookup("protobuf"); cfg.protobuf && ok && isProto64(original) {
+		// protojson represents 64-bit integers as strings
+		tag = addTagOption(tag, "json", "string")
+	}
+
+	if isQuotableField(original) != isQuotableField(field.Type) {
+		// e.g. []byte with BytesAsString: encoding/json ignored the option for the original type but would now
+		// encode the string twice, or a type override to a struct that it cannot decode from a string
+		tag = removeTagOption(tag, "json", "string")
+	}
+
+	if cfg.omitEmpty && field.Type.Kind() == reflect.Pointer {
+		tag = addTagOption(tag, "json", "omitempty")
+	}
+
+	if cfg.omitZero && field.Type.Kind() == reflect.Pointer {
+		tag = addTagOption(tag, "json", "omitzero")
+	}
+
+	if cfg.omitNil && field.Type.Kind() == reflect.Pointer {
+		if value, ok := tag.Lookup("validate"); ok && value != "" && !hasPresenceRule(value) {
+			tag = setTag(tag, "validate", "omitnil,"+value)
+		}
+	}
+
+	if cfg.validateRequired && field.Type.Kind() == reflect.Pointer {
+		value, ok := tag.Lookup("validate")
+		switch {
+		case !ok && original.Kind() != reflect.Pointer:
+			tag = setTag(tag, "validate", "required")
+		case ok && value != "" && !hasPresenceRule(value):
+			tag = setTag(tag, "validate", "omitnil,"+value)
+		}
+	}
+
+	return tag
+}
+
+// hasPresenceRule returns true if the validate tag value determines how a missing field is handled, i.e. it
+// contains required, omitnil or omitempty (or skips validation with -) before any dive
+func hasPresenceRule(value string) bool {
+	for _, rule := range strings.Split(value, ",") {
+		switch name, _, _ := strings.Cut(rule, "="); name {
+		case "dive", "keys":
+			return false
+		case "-", "omitnil", "omitempty":
+			return true
+		default:
+			if strings.HasPrefix(name, "required") {
+				return true
+			}
+		}
+	}
+	return false
+}
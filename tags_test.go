@@ -0,0 +1,126 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	// Arrange
+	tag := reflect.StructTag(`json:"name,omitempty" validate:"required" db:"na\"me"`)
+
+	// Act
+	pairs := parseTag(tag)
+
+	// Assert
+	assert.Equal(t, []tagPair{
+		{key: "json", value: "name,omitempty"},
+		{key: "validate", value: "required"},
+		{key: "db", value: `na"me`},
+	}, pairs)
+	assert.Equal(t, tag, formatTag(pairs))
+}
+
+func TestSetTag(t *testing.T) {
+	tests := map[string]struct {
+		Tag    reflect.StructTag
+		Output reflect.StructTag
+	}{
+		"empty":    {Tag: ``, Output: `json:"name"`},
+		"existing": {Tag: `json:"other" db:"name"`, Output: `json:"name" db:"name"`},
+		"append":   {Tag: `db:"name"`, Output: `db:"name" json:"name"`},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output := setTag(testData.Tag, "json", "name")
+
+			// Assert
+			assert.Equal(t, testData.Output, output)
+		})
+	}
+}
+
+func TestAddTagOption(t *testing.T) {
+	tests := map[string]struct {
+		Tag    reflect.StructTag
+		Output reflect.StructTag
+	}{
+		"no tag":      {Tag: ``, Output: `json:",omitempty"`},
+		"name only":   {Tag: `json:"name"`, Output: `json:"name,omitempty"`},
+		"with option": {Tag: `json:"name,string"`, Output: `json:"name,string,omitempty"`},
+		"present":     {Tag: `json:"name,omitempty"`, Output: `json:"name,omitempty"`},
+		"ignored":     {Tag: `json:"-"`, Output: `json:"-"`},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output := addTagOption(testData.Tag, "json", "omitempty")
+
+			// Assert
+			assert.Equal(t, testData.Output, output)
+		})
+	}
+}
+
+func TestRemoveTagOption(t *testing.T) {
+	tests := map[string]struct {
+		Tag    reflect.StructTag
+		Output reflect.StructTag
+	}{
+		"no tag":      {Tag: ``, Output: ``},
+		"absent":      {Tag: `json:"id,omitempty"`, Output: `json:"id,omitempty"`},
+		"only option": {Tag: `json:"id,string" db:"id"`, Output: `json:"id" db:"id"`},
+		"with others": {Tag: `json:"id,omitempty,string,omitzero"`, Output: `json:"id,omitempty,omitzero"`},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output := removeTagOption(testData.Tag, "json", "string")
+
+			// Assert
+			assert.Equal(t, testData.Output, output)
+		})
+	}
+}
+
+func TestRemoveTag(t *testing.T) {
+	// Arrange
+	tag := reflect.StructTag(`json:"name" gorm:"column:name" db:"name"`)
+
+	// Act
+	output := removeTag(tag, "gorm")
+
+	// Assert
+	assert.Equal(t, reflect.StructTag(`json:"name" db:"name"`), output)
+}
+
+func TestHasPresenceRule(t *testing.T) {
+	tests := map[string]struct {
+		Value  string
+		Output bool
+	}{
+		"required":            {Value: "required,email", Output: true},
+		"required_if":         {Value: "required_if=Kind a", Output: true},
+		"omitnil":             {Value: "omitnil,email", Output: true},
+		"omitempty":           {Value: "omitempty,min=1", Output: true},
+		"skip":                {Value: "-", Output: true},
+		"format only":         {Value: "email", Output: false},
+		"required after dive": {Value: "min=1,dive,required", Output: false},
+		"or group":            {Value: "uuid|email", Output: false},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output := hasPresenceRule(testData.Value)
+
+			// Assert
+			assert.Equal(t, testData.Output, output)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package nullify
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NullifyToMap returns the nullified version of obj as a nested map[string]any skeleton keyed by json name (see
+// WithTagPriority), where nested structs are maps themselves and all other fields are nil placeholders. Embedded
+// structs without a json name are flattened into their parent like encoding/json does. It returns nil if obj is not
+// a struct.
+func NullifyToMap(obj any, options ...option) map[string]any {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil
+	}
+
+	t := nullifiedType(typeOf, options...).Elem()
+	if !isNullifiedStruct(t) {
+		return nil
+	}
+
+	skeleton := map[string]any{}
+	fillSkeleton(skeleton, t, newConfig(options...))
+	return skeleton
+}
+
+// fillSkeleton adds the fields of the nullified struct t to skeleton
+func fillSkeleton(skeleton map[string]any, t reflect.Type, cfg config) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := fieldName(field, cfg)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if !isNullifiedStruct(fieldType) {
+			skeleton[name] = nil
+			continue
+		}
+
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && tagName == "" {
+			fillSkeleton(skeleton, fieldType, cfg)
+			continue
+		}
+
+		nested := map[string]any{}
+		fillSkeleton(nested, fieldType, cfg)
+		skeleton[name] = nested
+	}
+}
@@ -0,0 +1,44 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNullifyToMap(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name"`
+		Secret  string   `json:"-"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Other   Address
+		private string
+	}
+
+	// Act
+	skeleton := NullifyToMap(Person{}, JsonOptions...)
+
+	// Assert
+	assert.Equal(t, map[string]any{
+		"id":      nil,
+		"name":    nil,
+		"tags":    nil,
+		"address": map[string]any{"street": nil},
+		"Other":   map[string]any{"street": nil},
+	}, skeleton)
+}
+
+func TestNullifyToMap_NotAStruct(t *testing.T) {
+	// Act & Assert
+	assert.Nil(t, NullifyToMap(nil))
+	assert.Nil(t, NullifyToMap("string"))
+	assert.Nil(t, NullifyToMap(map[string]int{}))
+}
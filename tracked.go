@@ -0,0 +1,61 @@
+package nullify
+
+import (
+	"slices"
+	"sort"
+)
+
+// Tracked wraps a decoded nullified value and records the fields set programmatically afterwards (e.g. defaults or
+// server-side enrichment) separately from the fields the client provided, such that both can be reported.
+type Tracked struct {
+	value    any
+	provided []string
+	added    []string
+	cfg      config
+}
+
+// Track wraps the nullified value, treating its fields that are currently set as provided by the client. Paths are
+// dotted json paths like the keys of Flatten, names follow WithTagPriority.
+func Track(nullified any, options ...option) *Tracked {
+	cfg := newConfig(options...)
+	cfg.copyOnWrite = true // values set later must not write through pointers shared with the client payload
+
+	provided := make([]string, 0)
+	for path := range Flatten(nullified, options...) {
+		provided = append(provided, path)
+	}
+	sort.Strings(provided)
+
+	return &Tracked{value: nullified, provided: provided, cfg: cfg}
+}
+
+// Value returns the wrapped nullified value
+func (t *Tracked) Value() any {
+	return t.value
+}
+
+// Set sets the field at the dotted path (json or Go field names, e.g. address.city) to value, allocating nil
+// structs on the path. The value is copied like CopyMatching does, e.g. a string is set into a *string field and an
+// original struct into its nullified version. A nil value unsets the field.
+func (t *Tracked) Set(path string, value any) error {
+	normalized, err := setPath(t.value, path, value, t.cfg)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(t.added, normalized) {
+		t.added = append(t.added, normalized)
+		sort.Strings(t.added)
+	}
+	return nil
+}
+
+// Provided returns the sorted paths of the fields that were set when the value was wrapped, i.e. sent by the client
+func (t *Tracked) Provided() []string {
+	return slices.Clone(t.provided)
+}
+
+// Added returns the sorted paths passed to Set, including fields that overwrote a provided value
+func (t *Tracked) Added() []string {
+	return slices.Clone(t.added)
+}
@@ -0,0 +1,105 @@
+package nullify
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestTracked(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Base
+		Name    string  `json:"name"`
+		Age     int     `json:"age"`
+		Address Address `json:"address"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"name": "alice", "address": {"street": "Main St"}}`), p); err != nil {
+		t.Fatal(err)
+	}
+	street := reflect.ValueOf(p).Elem().FieldByName("Address").Elem().FieldByName("Street").Interface().(*string)
+
+	// Act
+	tracked := Track(p)
+	errs := []error{
+		tracked.Set("id", "1"),
+		tracked.Set("Age", 42),
+		tracked.Set("address.street", "Side St"),
+		tracked.Set("address.city", "Springfield"),
+		tracked.Set("name", nil),
+	}
+
+	// Assert
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"address.street", "name"}, tracked.Provided())
+	assert.Equal(t, []string{"address.city", "address.street", "age", "id", "name"}, tracked.Added())
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1", "name": null, "age": 42, "address": {"street": "Side St", "city": "Springfield"}}`,
+		string(b))
+	assert.Equal(t, "Main St", *street)
+}
+
+func TestTracked_Set_Errors(t *testing.T) {
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Base
+		Name    string  `json:"name"`
+		Age     int     `json:"age"`
+		Address Address `json:"address"`
+	}
+
+	tests := map[string]struct {
+		Path     string
+		Value    any
+		Expected string
+	}{
+		"unknown field": {
+			Path:     "address.zip",
+			Value:    "1234",
+			Expected: "nullify: address.zip: unknown field",
+		},
+		"not a struct": {
+			Path:     "name.first",
+			Value:    "alice",
+			Expected: "nullify: name: not a struct",
+		},
+		"type mismatch": {
+			Path:     "age",
+			Value:    "old",
+			Expected: "nullify: age: cannot copy string into int",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tracked := Track(Nullify(Person{}))
+
+			// Act
+			err := tracked.Set(testData.Path, testData.Value)
+
+			// Assert
+			assert.EqualError(t, err, testData.Expected)
+			assert.Empty(t, tracked.Added())
+		})
+	}
+}
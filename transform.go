@@ -0,0 +1,71 @@
+package nullify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Transformer modifies the fields of a struct in place, e.g. the *mold.Transformer of go-playground/mold created
+// with modifiers.New() to trim, lowercase or default strings using `mod:"trim,lcase"` tags
+type Transformer interface {
+	Struct(ctx context.Context, v any) error
+}
+
+// Transform registers a Transformer that DecodeAndValidate and Middleware run over the nullified value after it is
+// decoded and before it is validated (see ApplyTransform). Multiple transformers run in the order they are passed.
+type Transform struct {
+	Value Transformer
+}
+
+func (o Transform) update(cfg config) config {
+	cfg.transformers = append(slices.Clip(cfg.transformers), o.Value)
+	return cfg
+}
+
+// ApplyTransform runs t over the nullified value, leaving the fields that are not set (nil) untouched such that
+// modifiers like default cannot make absent fields appear to be provided
+func ApplyTransform(ctx context.Context, t Transformer, nullified any) error {
+	v, ok := indirect(reflect.ValueOf(nullified))
+	if !ok || !isNullifiedStruct(v.Type()) {
+		return fmt.Errorf("nullify: nullified must be a pointer to a nullified struct, got %T", nullified)
+	}
+
+	unset := unsetFields(v, nil)
+	if err := t.Struct(ctx, nullified); err != nil {
+		return err
+	}
+	for _, field := range unset {
+		field.SetZero()
+	}
+	return nil
+}
+
+// unsetFields appends the nil fields of the nullified struct v and of the nested nullified structs that are set
+func unsetFields(v reflect.Value, unset []reflect.Value) []reflect.Value {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		if isNil(field) {
+			unset = append(unset, field)
+			continue
+		}
+		if value, ok := indirect(field); ok && isNullifiedStruct(value.Type()) {
+			unset = unsetFields(value, unset)
+		}
+	}
+	return unset
+}
+
+// applyTransforms runs the transformers registered with the Transform option over the nullified value
+func applyTransforms(ctx context.Context, nullified any, cfg config) error {
+	for _, t := range cfg.transformers {
+		if err := ApplyTransform(ctx, t, nullified); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package nullify
+
+import (
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// modTransformer mimics mold with the trim and default modifiers: it trims every string field and sets nil string
+// fields to "default"
+type modTransformer struct{}
+
+func (modTransformer) Struct(_ context.Context, v any) error {
+	modStrings(reflect.ValueOf(v).Elem())
+	return nil
+}
+
+func modStrings(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Type() == reflect.TypeOf((*string)(nil)) && field.IsNil():
+			value := "default"
+			field.Set(reflect.ValueOf(&value))
+		case field.Type() == reflect.TypeOf((*string)(nil)):
+			field.Elem().SetString(strings.TrimSpace(field.Elem().String()))
+		case field.Kind() == reflect.Pointer && field.Elem().Kind() == reflect.Struct:
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			modStrings(field.Elem())
+		}
+	}
+}
+
+type errTransformer struct{}
+
+func (errTransformer) Struct(context.Context, any) error {
+	return errors.New("failed")
+}
+
+func TestApplyTransform(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name" validate:"required,alpha"`
+		Email   string   `json:"email"`
+		Address *Address `json:"address"`
+		Work    *Address `json:"work"`
+	}
+
+	p := Nullify(Person{})
+	v := reflect.ValueOf(p).Elem()
+	name, street := " alice ", " Main St "
+	v.FieldByName("Name").Set(reflect.ValueOf(&name))
+	address := reflect.New(v.FieldByName("Address").Type().Elem())
+	address.Elem().FieldByName("Street").Set(reflect.ValueOf(&street))
+	v.FieldByName("Address").Set(address)
+
+	// Act
+	err := ApplyTransform(context.Background(), modTransformer{}, p)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "alice", "address.street": "Main St"}, Flatten(p))
+}
+
+func TestApplyTransform_Error(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name" validate:"required,alpha"`
+		Email   string   `json:"email"`
+		Address *Address `json:"address"`
+		Work    *Address `json:"work"`
+	}
+
+	// Act
+	errNotNullified := ApplyTransform(context.Background(), modTransformer{}, &Person{})
+	errTransform := ApplyTransform(context.Background(), errTransformer{}, Nullify(Person{}))
+
+	// Assert
+	assert.EqualError(t, errNotNullified, "nullify: nullified must be a pointer to a nullified struct, got *nullify.Person")
+	assert.EqualError(t, errTransform, "failed")
+}
+
+func TestDecodeAndValidate_Transform(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Name    string   `json:"name" validate:"required,alpha"`
+		Email   string   `json:"email"`
+		Address *Address `json:"address"`
+		Work    *Address `json:"work"`
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": " alice ", "address": {"city": " Springfield "}}`))
+	person := Person{Email: "alice@example.com"}
+
+	// Act
+	err := DecodeAndValidate(r, &person, validator.New(), WithTransform(modTransformer{}))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Person{
+		Name:    "alice",
+		Email:   "alice@example.com",
+		Address: &Address{City: "Springfield"},
+	}, person)
+}
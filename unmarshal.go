@@ -0,0 +1,141 @@
+package nullify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Unmarshal decodes the JSON data into the nullified type of dst, copies the fields that were present into dst
+// (see CopyMatching) and returns the nullified instance, e.g. to validate it or check which fields were sent.
+//
+// dst must be a non-nil pointer.
+func Unmarshal(data []byte, dst any, options ...option) (presence any, err error) {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Pointer || dstVal.IsNil() {
+		return nil, fmt.Errorf("nullify: dst must be a non-nil pointer, got %T", dst)
+	}
+
+	presence = newNullified(dst, options...)
+	if err := json.Unmarshal(data, presence); err != nil {
+		return nil, err
+	}
+
+	if err := CopyMatching(presence, dst, options...); err != nil {
+		return nil, err
+	}
+
+	return presence, nil
+}
+
+// UnknownFieldsError is returned by UnmarshalStrict for input containing keys that do not match any field
+type UnknownFieldsError struct {
+	Paths []string // sorted json paths of the unknown keys, e.g. address.zip or items[0].extra
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "nullify: unknown fields: " + strings.Join(e.Paths, ", ")
+}
+
+// UnmarshalStrict decodes the JSON data into a new instance of the nullified type of prototype, rejecting keys that
+// do not match any field like json.Decoder.DisallowUnknownFields does, as well as data after the top-level value.
+// Unknown keys are reported as an *UnknownFieldsError naming all of them rather than just the first.
+func UnmarshalStrict(data []byte, prototype any, options ...option) (any, error) {
+	presence := newNullified(prototype, options...)
+	if presence == nil {
+		return nil, fmt.Errorf("nullify: cannot unmarshal into nil")
+	}
+
+	var raw json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("nullify: invalid data after top-level value")
+	}
+
+	// encoding/json stops at the first unknown field, so the keys are checked against the fields up front
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	if paths := unknownFields(generic, reflect.TypeOf(presence), ""); len(paths) > 0 {
+		sort.Strings(paths)
+		return nil, &UnknownFieldsError{Paths: paths}
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(presence); err != nil {
+		return nil, err
+	}
+	return presence, nil
+}
+
+// unknownFields returns the json paths of the object keys in the decoded json value that do not match a field of t
+func unknownFields(value any, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(jsonUnmarshaler) || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return nil
+	}
+
+	var paths []string
+	switch value := value.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			for key, elem := range value {
+				field, ok := jsonField(t, key)
+				if !ok {
+					paths = append(paths, joinPath(path, key))
+					continue
+				}
+				paths = append(paths, unknownFields(elem, field.Type, joinPath(path, key))...)
+			}
+		case reflect.Map:
+			for key, elem := range value {
+				paths = append(paths, unknownFields(elem, t.Elem(), fmt.Sprintf("%s[%s]", path, key))...)
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, elem := range value {
+				paths = append(paths, unknownFields(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return paths
+}
+
+// jsonField returns the field of the struct type t that encoding/json decodes key into: the field with that json
+// name, or else with a case-insensitively matching name. Fields of embedded structs without a json name are
+// included.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fold *reflect.StructField
+	for _, field := range reflect.VisibleFields(t) {
+		name, ok := jsonName(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && tagName == "" {
+			continue // flattened, its fields are visible themselves
+		}
+		if name == key {
+			return field, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			fold = &field
+		}
+	}
+	if fold != nil {
+		return *fold, true
+	}
+	return reflect.StructField{}, false
+}
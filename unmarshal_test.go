@@ -0,0 +1,145 @@
+package nullify
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name   string `json:"name"`
+		Age    int    `json:"age"`
+		Avatar []byte `json:"avatar"`
+	}
+
+	person := Person{Name: "alice", Age: 30}
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"age": 31, "avatar": "aGVsbG8="}`), &person, JsonOptions...)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Person{Name: "alice", Age: 31, Avatar: []byte("hello")}, person)
+	assert.True(t, reflect.ValueOf(presence).Elem().Field(0).IsNil())
+	assert.Equal(t, 31, reflect.ValueOf(presence).Elem().Field(1).Elem().Interface())
+}
+
+func TestUnmarshal_ValueResult(t *testing.T) {
+	// Arrange
+	type Person struct {
+		Name string `json:"name"`
+	}
+	var person Person
+
+	// Act
+	presence, err := Unmarshal([]byte(`{"name": "alice"}`), &person, ValueResult{Value: true})
+	strict, strictErr := UnmarshalStrict([]byte(`{"name": "bob"}`), Person{}, ValueResult{Value: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Person{Name: "alice"}, person)
+	assert.Equal(t, reflect.Pointer, reflect.TypeOf(presence).Kind())
+	assert.NoError(t, strictErr)
+	assert.Equal(t, "bob", *reflect.ValueOf(strict).Elem().Field(0).Interface().(*string))
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	type Person struct {
+		Name string `json:"name"`
+	}
+
+	tests := map[string]struct {
+		Data         string
+		Dst          any
+		ErrorMessage string
+	}{
+		"non-pointer dst": {Data: `{}`, Dst: Person{}, ErrorMessage: "nullify: dst must be a non-nil pointer, got nullify.Person"},
+		"invalid json":    {Data: `{`, Dst: &Person{}, ErrorMessage: "unexpected end of JSON input"},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			presence, err := Unmarshal([]byte(testData.Data), testData.Dst)
+
+			// Assert
+			assert.Nil(t, presence)
+			assert.ErrorContains(t, err, testData.ErrorMessage)
+		})
+	}
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Item struct {
+		SKU string `json:"sku"`
+	}
+	type Order struct {
+		Base
+		Customer string          `json:"customer"`
+		Items    []Item          `json:"items"`
+		Meta     map[string]Item `json:"meta"`
+		Extra    json.RawMessage `json:"extra"`
+		Any      any             `json:"any"`
+	}
+
+	tests := map[string]struct {
+		Data         string
+		ErrorMessage string
+		Paths        []string
+	}{
+		"known fields": {
+			Data: `{"id": "1", "CUSTOMER": "alice", "items": [{"sku": "a"}], "meta": {"k": {"sku": "b"}},
+				"extra": {"free": "form"}, "any": {"free": "form"}}`,
+		},
+		"unknown fields": {
+			Data:         `{"id": "1", "zip": "1234", "items": [{"sku": "a"}, {"qty": 1}], "meta": {"k": {"x": 1}}}`,
+			ErrorMessage: "nullify: unknown fields: items[1].qty, meta[k].x, zip",
+			Paths:        []string{"items[1].qty", "meta[k].x", "zip"},
+		},
+		"unknown field after invalid value": {
+			Data:         `{"id": 1, "zip": "1234"}`,
+			ErrorMessage: "nullify: unknown fields: zip",
+			Paths:        []string{"zip"},
+		},
+		"trailing data": {
+			Data:         `{"id": "1"} {"id": "2"}`,
+			ErrorMessage: "nullify: invalid data after top-level value",
+		},
+		"trailing garbage": {
+			Data:         `{"id": "1"} xyz`,
+			ErrorMessage: "nullify: invalid data after top-level value",
+		},
+		"invalid json": {
+			Data:         `{"id": 1}`,
+			ErrorMessage: "json: cannot unmarshal",
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			presence, err := UnmarshalStrict([]byte(testData.Data), Order{})
+
+			// Assert
+			if testData.ErrorMessage == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, presence)
+				return
+			}
+			assert.Nil(t, presence)
+			assert.ErrorContains(t, err, testData.ErrorMessage)
+
+			var unknownFieldsErr *UnknownFieldsError
+			if assert.Equal(t, testData.Paths != nil, errors.As(err, &unknownFieldsErr)) && testData.Paths != nil {
+				assert.Equal(t, testData.Paths, unknownFieldsErr.Paths)
+			}
+		})
+	}
+}
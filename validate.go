@@ -0,0 +1,155 @@
+package nullify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// StructValidator validates the fields of a struct, e.g. *validator.Validate of github.com/go-playground/validator
+type StructValidator interface {
+	StructCtx(ctx context.Context, s any) error
+}
+
+// validatorFieldError is the part of validator.FieldError of github.com/go-playground/validator used to report
+// failures, such that the validator is not a dependency of this package
+type validatorFieldError interface {
+	StructNamespace() string
+	Tag() string
+	Param() string
+	Value() any
+}
+
+// FieldError is the validation failure of a single field, referenced by the json path clients sent
+type FieldError struct {
+	Path  string `json:"path"`            // json path of the field, e.g. address.street or tags[0]
+	Tag   string `json:"tag"`             // validation tag that failed, e.g. required
+	Param string `json:"param,omitempty"` // parameter of the validation tag, e.g. 5 for min=5
+}
+
+func (e FieldError) Error() string {
+	return "Key: '" + e.Path + "' Error:Field validation for '" + e.Path + "' failed on the '" + e.Tag + "' tag"
+}
+
+// ValidationErrors are the validation failures of a nullified value
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate validates the nullified value with v, returning ValidationErrors that reference the json names clients sent
+// (e.g. address.street rather than Address.Street) if validation fails
+func Validate(v StructValidator, nullified any) error {
+	return validationErrors(nullified, v.StructCtx(context.Background(), nullified))
+}
+
+// JSONTagName returns the json name of field, or "-" if the field is ignored by encoding/json. Register it with
+// validator.Validate.RegisterTagNameFunc such that validator.FieldError.Namespace and validator.FieldError.Field report
+// json names.
+func JSONTagName(field reflect.StructField) string {
+	if name, ok := jsonName(field); ok {
+		return name
+	}
+	return "-"
+}
+
+// validationErrors converts validator.ValidationErrors produced for value into ValidationErrors referencing json
+// paths, other errors are returned as is
+func validationErrors(value any, err error) error {
+	errs, ok := fieldErrors(err)
+	if !ok {
+		return err
+	}
+
+	fieldErrors := make(ValidationErrors, len(errs))
+	for i, fieldError := range errs {
+		fieldErrors[i] = FieldError{
+			Path:  jsonPath(reflect.TypeOf(value), fieldError.StructNamespace()),
+			Tag:   fieldError.Tag(),
+			Param: fieldError.Param(),
+		}
+	}
+	return fieldErrors
+}
+
+// fieldErrors returns the field errors of the first error in the chain of err that is a slice of field errors (e.g.
+// validator.ValidationErrors), false if there is none
+func fieldErrors(err error) ([]validatorFieldError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() != reflect.Slice || v.Len() == 0 {
+			continue
+		}
+
+		errs := make([]validatorFieldError, v.Len())
+		for i := range errs {
+			fieldError, ok := v.Index(i).Interface().(validatorFieldError)
+			if !ok {
+				return nil, false
+			}
+			errs[i] = fieldError
+		}
+		return errs, true
+	}
+	return nil, false
+}
+
+// jsonPath converts the namespace of Go field names reported by the validator (e.g. Address.Street or Tags[0])
+// into the json path (address.street or tags[0]) by resolving the fields in t
+func jsonPath(t reflect.Type, namespace string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	segments := strings.Split(namespace, ".")
+	if t.Name() != "" && len(segments) > 1 {
+		segments = segments[1:] // strip the name of a named top-level struct, nullified structs are unnamed
+	}
+
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			path = append(path, segment)
+			continue
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			path = append(path, segment)
+			continue
+		}
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		embedded := field.Anonymous && tagName == "" && index == "" && indirectType(field.Type).Kind() == reflect.Struct
+		if jsonName, ok := jsonName(field); ok {
+			name = jsonName
+		}
+		if !embedded {
+			path = append(path, name+index) // embedded structs are flattened into their parent like encoding/json does
+		}
+
+		t = field.Type
+		for i := strings.Count(index, "["); i > 0; i-- {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				t = t.Elem()
+			}
+		}
+	}
+	return strings.Join(path, ".")
+}
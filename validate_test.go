@@ -0,0 +1,154 @@
+package nullify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	tests := map[string]struct {
+		Payload string
+		Error   error
+	}{
+		"valid": {
+			Payload: `{"name": "alice", "address": {"street": "Main St"}}`,
+		},
+		"missing": {
+			Payload: `{"address": {}}`,
+			Error: ValidationErrors{
+				{Path: "name", Tag: "required"},
+				{Path: "address.street", Tag: "required"},
+			},
+		},
+		"invalid": {
+			Payload: `{"name": "alice", "email_address": "invalid", "friends": [{}]}`,
+			Error: ValidationErrors{
+				{Path: "email_address", Tag: "email"},
+				{Path: "friends[0].street", Tag: "required"},
+			},
+		},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			p := Nullify(Person{}, JsonOptions...)
+			if err := json.Unmarshal([]byte(testData.Payload), p); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			err := Validate(validator.New(), p)
+
+			// Assert
+			if testData.Error == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testData.Error, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Embedded(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id" validate:"omitnil,min=2"`
+	}
+	type Person struct {
+		Base
+		Name string `json:"name" validate:"required"`
+	}
+	p := Nullify(Person{}, JsonOptions...)
+	if err := json.Unmarshal([]byte(`{"name": "alice", "id": "a"}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err := Validate(validator.New(), p)
+
+	// Assert
+	assert.Equal(t, ValidationErrors{{Path: "id", Tag: "min", Param: "2"}}, err)
+}
+
+func TestValidate_InvalidValidation(t *testing.T) {
+	// Act
+	err := Validate(validator.New(), "no struct")
+
+	// Assert
+	var invalid *validator.InvalidValidationError
+	assert.True(t, errors.As(err, &invalid))
+}
+
+func TestValidationErrors_Wrapped(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	p := Nullify(Person{}, JsonOptions...)
+	if err := json.Unmarshal([]byte(`{"name": "alice", "address": {}}`), p); err != nil {
+		t.Fatal(err)
+	}
+	other := errors.New("validator unavailable")
+
+	// Act
+	err := validationErrors(p, fmt.Errorf("validate: %w", validator.New().Struct(p)))
+	otherErr := validationErrors(p, other)
+
+	// Assert
+	assert.Equal(t, ValidationErrors{{Path: "address.street", Tag: "required"}}, err)
+	assert.Equal(t, other, otherErr)
+}
+
+func TestJSONTagName(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Email   string    `json:"email_address" validate:"omitnil,email"`
+		Address Address   `json:"address"`
+		Friends []Address `json:"friends" validate:"omitnil,dive"`
+	}
+
+	v := validator.New()
+	v.RegisterTagNameFunc(JSONTagName)
+	p := Nullify(Person{}, JsonOptions...)
+	if err := json.Unmarshal([]byte(`{"email_address": "invalid", "address": {}}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err := v.Struct(p)
+
+	// Assert
+	var errs validator.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	fields := make([]string, len(errs))
+	for i, fieldError := range errs {
+		fields[i] = fieldError.Field()
+	}
+	assert.Equal(t, []string{"name", "email_address", "street"}, fields)
+}
@@ -0,0 +1,284 @@
+package nullify
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	anyType         = reflect.TypeOf((*any)(nil)).Elem()
+	durationType    = reflect.TypeOf(time.Duration(0))
+	jsonNumberType  = reflect.TypeOf(json.Number(""))
+	timeType        = reflect.TypeOf(time.Time{})
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// indirect dereferences pointers and interfaces until a non-pointer value is reached, false if a nil pointer or
+// interface is encountered (i.e. the value is not set)
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// isNullifiedStruct returns true if t is a struct built by Nullify, i.e. an unnamed struct type. Named struct
+// types (e.g. time.Time) are leaves that were not decomposed.
+func isNullifiedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Name() == ""
+}
+
+// plain returns v with all pointers dereferenced, such that it prints and serializes as its values rather than as
+// addresses: nullified structs become a map[string]any of their set fields keyed by Go field name, slices and
+// arrays become []any and maps become map[any]any. It returns false if v is not set.
+func plain(v reflect.Value) (any, bool) {
+	v, ok := indirect(v)
+	if !ok {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !isNullifiedStruct(v.Type()) {
+			break
+		}
+		fields := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			if value, ok := plain(v.Field(i)); ok && v.Type().Field(i).IsExported() {
+				fields[v.Type().Field(i).Name] = value
+			}
+		}
+		return fields, true
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, false
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		elems := make([]any, v.Len())
+		for i := range elems {
+			elems[i], _ = plain(v.Index(i))
+		}
+		return elems, true
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, false
+		}
+		entries := make(map[any]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, _ := plain(iter.Key())
+			entries[key], _ = plain(iter.Value())
+		}
+		return entries, true
+	}
+
+	if !v.CanInterface() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// formatText returns the text form of v that parseText parses back: the text of encoding.TextMarshaler
+// implementations, strconv formatting for booleans and numbers, the String method of fmt.Stringer implementations
+// and JSON for anything else
+func formatText(v reflect.Value) (string, error) {
+	if reflect.PointerTo(v.Type()).Implements(textMarshaler) && v.Kind() != reflect.Pointer {
+		text, err := addressable(v).Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	if v.Type().Implements(textMarshaler) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String(), nil
+	}
+	data, err := json.Marshal(v.Interface())
+	return string(data), err
+}
+
+// addressable returns a pointer to v, copying v if it is not addressable, such that methods with pointer receivers
+// (e.g. those of big.Int) are used
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// isTextLeaf returns true if t is a struct that can only be marshalled to and from text through a pointer, e.g.
+// big.Int. Such values must not be copied by assignment as the copy shares its internal state with the original.
+func isTextLeaf(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !t.Implements(textMarshaler) &&
+		reflect.PointerTo(t).Implements(textMarshaler) && reflect.PointerTo(t).Implements(textUnmarshaler)
+}
+
+// parseText parses s into v according to its type, allocating pointers as needed
+func parseText(v reflect.Value, s string) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		v.SetInt(int64(d))
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+	return nil
+}
+
+// present is indirect treating nil slices and maps as not set as well when cfg.bareContainers is set, as their
+// pointer wrapper that records presence otherwise is left out
+func present(v reflect.Value, cfg config) (reflect.Value, bool) {
+	v, ok := indirect(v)
+	if ok && cfg.bareContainers && isNil(v) {
+		return v, false
+	}
+	return v, ok
+}
+
+// isNil returns true if v is a nil pointer, interface, slice or map
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// fieldByPath returns the field of the nullified struct v at the dotted path of field names (json names following
+// cfg, or Go field names) together with the normalized path of json names. Embedded structs without a json name are
+// searched like encoding/json does. Nil structs on the path are allocated if alloc is set, otherwise they are an
+// error.
+func fieldByPath(v reflect.Value, path string, alloc bool, cfg config) (reflect.Value, string, error) {
+	normalized := ""
+	for _, segment := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() && (!alloc || !v.CanSet()) {
+				return reflect.Value{}, "", fmt.Errorf("nullify: %s: not set", pathOrRoot(normalized))
+			}
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if !isNullifiedStruct(v.Type()) {
+			return reflect.Value{}, "", fmt.Errorf("nullify: %s: not a struct", pathOrRoot(normalized))
+		}
+
+		field, name, ok := structField(v, segment, alloc, cfg)
+		if !ok {
+			return reflect.Value{}, "", fmt.Errorf("nullify: %s: unknown field", joinPath(normalized, segment))
+		}
+		v = field
+		normalized = joinPath(normalized, name)
+	}
+	return v, normalized, nil
+}
+
+// structField returns the field of the struct value v named name (see fieldByPath) and its json name
+func structField(v reflect.Value, name string, alloc bool, cfg config) (reflect.Value, string, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		fieldName, ok := fieldName(field, cfg)
+		if ok && field.IsExported() && (fieldName == name || field.Name == name) {
+			return v.Field(i), fieldName, true
+		}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); !field.Anonymous || tagName != "" {
+			continue
+		}
+
+		embedded := v.Field(i)
+		if embedded.Kind() != reflect.Pointer {
+			if isNullifiedStruct(embedded.Type()) {
+				if found, fieldName, ok := structField(embedded, name, alloc, cfg); ok {
+					return found, fieldName, true
+				}
+			}
+			continue
+		}
+		if !isNullifiedStruct(embedded.Type().Elem()) || (embedded.IsNil() && !alloc) {
+			continue
+		}
+
+		// allocate a nil embedded struct only if it holds the field
+		elem := embedded
+		if embedded.IsNil() {
+			elem = reflect.New(embedded.Type().Elem())
+		}
+		if found, fieldName, ok := structField(elem.Elem(), name, alloc, cfg); ok {
+			embedded.Set(elem)
+			return found, fieldName, true
+		}
+	}
+	return reflect.Value{}, "", false
+}
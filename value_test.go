@@ -0,0 +1,59 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIndirect(t *testing.T) {
+	// Arrange
+	name := "alice"
+	ptr := &name
+	var nilPtr *string
+	var iface any = &ptr
+
+	// Act
+	value, ok := indirect(reflect.ValueOf(iface))
+	_, nilOk := indirect(reflect.ValueOf(nilPtr))
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "alice", value.String())
+	assert.False(t, nilOk)
+}
+
+func TestPlain(t *testing.T) {
+	// Arrange
+	name := "alice"
+	tag := "a"
+	now := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	input := &struct {
+		Name    *string
+		Age     *int
+		Tags    *[]*string
+		Labels  *map[*string]*string
+		Created *time.Time
+		Avatar  *[]byte
+	}{
+		Name:    &name,
+		Tags:    &[]*string{&tag, nil},
+		Labels:  &map[*string]*string{&tag: &name},
+		Created: &now,
+		Avatar:  &[]byte{1},
+	}
+
+	// Act
+	output, ok := plain(reflect.ValueOf(input))
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{
+		"Name":    "alice",
+		"Tags":    []any{"a", nil},
+		"Labels":  map[any]any{"a": "alice"},
+		"Created": now,
+		"Avatar":  []byte{1},
+	}, output)
+}
@@ -0,0 +1,80 @@
+package nullify
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Variants returns instances of two types derived from the type of obj, e.g. for the PATCH and PUT endpoints of a
+// resource: patch is fully nullified like Nullify does, while in put only the fields tagged `nullify:"optional"`
+// are nullified and all other fields keep their original type. Nested structs without optional fields are kept as
+// is. Options apply to both variants. It returns nil for both if obj is the nil interface.
+func Variants(obj any, options ...option) (patch any, put any) {
+	typeOf := reflect.TypeOf(obj)
+	if typeOf == nil {
+		return nil, nil
+	}
+
+	cfg := newConfig(options...)
+	return Nullify(obj, options...), reflect.New(optionalType(typeOf, cfg)).Interface()
+}
+
+// optionalType returns t with only the fields tagged `nullify:"optional"` nullified
+func optionalType(t reflect.Type, cfg config) reflect.Type {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.PointerTo(optionalType(t.Elem(), cfg))
+	case reflect.Slice:
+		return reflect.SliceOf(optionalType(t.Elem(), cfg))
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), optionalType(t.Elem(), cfg))
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), optionalType(t.Elem(), cfg))
+	case reflect.Struct:
+		if slices.Contains(cfg.visiting, t) || !hasOptional(t, nil) {
+			return t
+		}
+	default:
+		return t
+	}
+
+	cfg.visiting = append(slices.Clip(cfg.visiting), t)
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("nullify") == "optional" {
+			if field, ok := nullifyField(field, cfg); ok {
+				fields = append(fields, field)
+			}
+			continue
+		}
+
+		fieldCfg := cfg
+		fieldCfg.path = joinPath(cfg.path, field.Name)
+		original := field.Type
+		field.Type = optionalType(field.Type, fieldCfg)
+		field.Tag = rewriteTag(field, original, fieldCfg)
+		if field = transformField(field, fieldCfg); field.Name != "" {
+			fields = append(fields, field)
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+// hasOptional returns true if the struct t or any struct nested in it has a field tagged `nullify:"optional"`
+func hasOptional(t reflect.Type, visited []reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || slices.Contains(visited, t) {
+		return false
+	}
+
+	visited = append(slices.Clip(visited), t)
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("nullify") == "optional" || hasOptional(t.Field(i).Type, visited) {
+			return true
+		}
+	}
+	return false
+}
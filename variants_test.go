@@ -0,0 +1,69 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVariants(t *testing.T) {
+	// Arrange
+	type Address struct {
+		Street string `json:"street"`
+		Line2  string `json:"line2" nullify:"optional"`
+	}
+	type Person struct {
+		Name      string    `json:"name"`
+		Nickname  string    `json:"nickname" nullify:"optional"`
+		Address   Address   `json:"address"`
+		Addresses []Address `json:"addresses"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// Act
+	patch, put := Variants(Person{}, JsonOptions...)
+
+	// Assert
+	assert.Equal(t, Nullify(Person{}, JsonOptions...), patch)
+	type address = struct {
+		Street string  `json:"street"`
+		Line2  *string `json:"line2" nullify:"optional"`
+	}
+	assert.Equal(t, reflect.TypeOf(struct {
+		Name      string    `json:"name"`
+		Nickname  *string   `json:"nickname" nullify:"optional"`
+		Address   address   `json:"address"`
+		Addresses []address `json:"addresses"`
+		CreatedAt time.Time `json:"created_at"`
+	}{}), reflect.TypeOf(put).Elem())
+}
+
+func TestVariants_WithoutOptional(t *testing.T) {
+	// Arrange
+	type Location struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		Line2  string `json:"line2" nullify:"optional"`
+	}
+
+	// Act
+	_, put := Variants(Address{Street: "Main St"}, StripTags{Value: []string{"nullify"}})
+	_, none := Variants(Location{})
+
+	// Assert
+	assert.Equal(t, "struct { Street string \"json:\\\"street\\\"\"; Line2 *string \"json:\\\"line2\\\"\" }", reflect.TypeOf(put).Elem().String())
+	assert.Equal(t, &Location{}, none)
+}
+
+func TestVariants_Nil(t *testing.T) {
+	// Act
+	patch, put := Variants(nil)
+
+	// Assert
+	assert.Nil(t, patch)
+	assert.Nil(t, put)
+}
@@ -0,0 +1,110 @@
+package nullify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SkipField can be returned by the function passed to Walk to skip the nested struct (or slice or map of structs)
+// held by the field. It is not returned by Walk.
+var SkipField = errors.New("nullify: skip field")
+
+// Walk calls fn for every exported field of the nullified value depth-first, including fields that are not set (nil).
+// Paths are dotted json paths like the keys of Flatten, with indices for the elements of slices and maps of structs
+// (e.g. friends[0].name), map entries are walked in the order of their keys. Set nested structs are walked after fn
+// was called for the field holding them, unless fn returns SkipField. Embedded structs without a json name are
+// walked as part of their parent like encoding/json flattens them, without calling fn for the embedded field itself
+// (the fields of a nil embedded struct are walked on a zero value, setting them has no effect).
+//
+// value is the field as stored in the nullified struct, i.e. a pointer that is nil if the field is not set, and can
+// be set to modify the value (e.g. to redact it) if nullified is a pointer. Fields of structs stored by value in a
+// map are walked on a copy, setting them has no effect. Walk stops at the first other error returned by fn and
+// returns it.
+func Walk(nullified any, fn func(path string, field reflect.StructField, value reflect.Value) error) error {
+	v, ok := indirect(reflect.ValueOf(nullified))
+	if !ok || !isNullifiedStruct(v.Type()) {
+		return fmt.Errorf("nullify: nullified must be a nullified struct, got %T", nullified)
+	}
+	return walkStruct(v, "", fn, newConfig())
+}
+
+// walkStruct calls fn for the fields of the nullified struct v, prefixing names with path
+func walkStruct(v reflect.Value, path string, fn func(string, reflect.StructField, reflect.Value) error,
+	cfg config) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := fieldName(field, cfg)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		if tagName, _, _ := strings.Cut(field.Tag.Get("json"), ","); field.Anonymous && tagName == "" {
+			embedded, ok := indirect(v.Field(i))
+			if !ok && v.Field(i).Kind() == reflect.Pointer {
+				embedded = reflect.New(v.Field(i).Type().Elem()).Elem() // not set, walk its zero value
+			}
+			if isNullifiedStruct(embedded.Type()) {
+				if err := walkStruct(embedded, path, fn, cfg); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		fieldPath := joinPath(path, name)
+		err := fn(fieldPath, field, v.Field(i))
+		if errors.Is(err, SkipField) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := walkValue(v.Field(i), fieldPath, fn, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkValue walks the nullified structs held by v, either directly or as elements of a slice, array or map
+func walkValue(v reflect.Value, path string, fn func(string, reflect.StructField, reflect.Value) error,
+	cfg config) error {
+	v, ok := indirect(v)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case isNullifiedStruct(v.Type()):
+		return walkStruct(v, path, fn, cfg)
+	case v.Kind() == reflect.Slice, v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn, cfg); err != nil {
+				return err
+			}
+		}
+	case v.Kind() == reflect.Map:
+		keys := make(map[string]reflect.Value, v.Len())
+		names := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			plainKey, _ := indirect(key)
+			name := fmt.Sprint(plainKey)
+			keys[name] = key
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			// map elements are not addressable, walk a copy
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(keys[name]))
+			if err := walkValue(elem, fmt.Sprintf("%s[%s]", path, name), fn, cfg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package nullify
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Base
+		Name     string             `json:"name"`
+		Password string             `json:"password"`
+		Address  Address            `json:"address"`
+		Friends  []Address          `json:"friends"`
+		Places   map[string]Address `json:"places"`
+		Secret   string             `json:"-"`
+	}
+
+	p := Nullify(Person{})
+	payload := `{"id": "1", "name": "alice", "address": {"city": "Springfield"}, "friends": [{"street": "Main St"}],
+		"places": {"work": {"city": "Shelbyville"}}}`
+	if err := json.Unmarshal([]byte(payload), p); err != nil {
+		t.Fatal(err)
+	}
+	visited := map[string]bool{}
+
+	// Act
+	err := Walk(p, func(path string, field reflect.StructField, value reflect.Value) error {
+		visited[path] = !value.IsNil()
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"id":                  true,
+		"name":                true,
+		"password":            false,
+		"address":             true,
+		"address.street":      false,
+		"address.city":        true,
+		"friends":             true,
+		"friends[0].street":   true,
+		"friends[0].city":     false,
+		"places":              true,
+		"places[work].street": false,
+		"places[work].city":   true,
+	}, visited)
+}
+
+func TestWalk_Redact(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Base
+		Name     string             `json:"name"`
+		Password string             `json:"password"`
+		Address  Address            `json:"address"`
+		Friends  []Address          `json:"friends"`
+		Places   map[string]Address `json:"places"`
+		Secret   string             `json:"-"`
+	}
+
+	p := Nullify(Person{})
+	if err := json.Unmarshal([]byte(`{"name": "alice", "password": "hunter2", "address": {"city": "x"}}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err := Walk(p, func(path string, field reflect.StructField, value reflect.Value) error {
+		switch {
+		case field.Name == "Password" && !value.IsNil():
+			redacted := "***"
+			value.Set(reflect.ValueOf(&redacted))
+		case path == "address":
+			return SkipField
+		case path == "address.city":
+			t.Error("skipped field was walked")
+		}
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "alice", "password": "***", "address.city": "x"}, Flatten(p))
+}
+
+func TestWalk_Error(t *testing.T) {
+	// Arrange
+	type Base struct {
+		ID string `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Person struct {
+		Base
+		Name     string             `json:"name"`
+		Password string             `json:"password"`
+		Address  Address            `json:"address"`
+		Friends  []Address          `json:"friends"`
+		Places   map[string]Address `json:"places"`
+		Secret   string             `json:"-"`
+	}
+
+	p := Nullify(Person{})
+	stop := errors.New("stop")
+	var visited []string
+
+	// Act
+	err := Walk(p, func(path string, field reflect.StructField, value reflect.Value) error {
+		visited = append(visited, path)
+		if path == "name" {
+			return stop
+		}
+		return nil
+	})
+	errNotNullified := Walk(&Person{}, func(string, reflect.StructField, reflect.Value) error { return nil })
+
+	// Assert
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"id", "name"}, visited)
+	assert.EqualError(t, errNotNullified, "nullify: nullified must be a nullified struct, got *nullify.Person")
+}
@@ -0,0 +1,154 @@
+package nullify
+
+// The functional constructors below are equivalent to the option structs they return, e.g. WithBytesAsString(true)
+// is BytesAsString{Value: true}, and can be mixed with them freely.
+
+// WithBytesAsString returns the BytesAsString option
+func WithBytesAsString(value bool) option {
+	return BytesAsString{Value: value}
+}
+
+// WithNullifyArrayElem returns the NullifyArrayElem option
+func WithNullifyArrayElem(value bool) option {
+	return NullifyArrayElem{Value: value}
+}
+
+// WithNullifySliceElem returns the NullifySliceElem option
+func WithNullifySliceElem(value bool) option {
+	return NullifySliceElem{Value: value}
+}
+
+// WithNullifyMapElem returns the NullifyMapElem option
+func WithNullifyMapElem(value bool) option {
+	return NullifyMapElem{Value: value}
+}
+
+// WithNullifyMapKey returns the NullifyMapKey option
+func WithNullifyMapKey(value bool) option {
+	return NullifyMapKey{Value: value}
+}
+
+// WithNullifyMarshalJson returns the NullifyMarshalJson option
+func WithNullifyMarshalJson(value bool) option {
+	return NullifyMarshalJson{Value: value}
+}
+
+// WithNullifyUnmarshalJson returns the NullifyUnmarshalJson option
+func WithNullifyUnmarshalJson(value bool) option {
+	return NullifyUnmarshalJson{Value: value}
+}
+
+// WithNullifySqlScanner returns the NullifySqlScanner option
+func WithNullifySqlScanner(value bool) option {
+	return NullifySqlScanner{Value: value}
+}
+
+// WithKeepNamedBytes returns the KeepNamedBytes option
+func WithKeepNamedBytes(value bool) option {
+	return KeepNamedBytes{Value: value}
+}
+
+// WithOmitEmpty returns the OmitEmpty option
+func WithOmitEmpty(value bool) option {
+	return OmitEmpty{Value: value}
+}
+
+// WithOmitZero returns the OmitZero option
+func WithOmitZero(value bool) option {
+	return OmitZero{Value: value}
+}
+
+// WithValidateRequired returns the ValidateRequired option
+func WithValidateRequired(value bool) option {
+	return ValidateRequired{Value: value}
+}
+
+// WithOmitNil returns the OmitNil option
+func WithOmitNil(value bool) option {
+	return OmitNil{Value: value}
+}
+
+// WithFlattenEmbedded returns the FlattenEmbedded option
+func WithFlattenEmbedded(value bool) option {
+	return FlattenEmbedded{Value: value}
+}
+
+// WithSafeMapKeys returns the SafeMapKeys option
+func WithSafeMapKeys(value bool) option {
+	return SafeMapKeys{Value: value}
+}
+
+// WithStringMapKeys returns the StringMapKeys option
+func WithStringMapKeys(value bool) option {
+	return StringMapKeys{Value: value}
+}
+
+// WithJsonNumbers returns the JsonNumbers option
+func WithJsonNumbers(value bool) option {
+	return JsonNumbers{Value: value}
+}
+
+// WithSkipUnserializable returns the SkipUnserializable option
+func WithSkipUnserializable(value bool) option {
+	return SkipUnserializable{Value: value}
+}
+
+// WithNullifyInterfaceElem returns the NullifyInterfaceElem option
+func WithNullifyInterfaceElem(value bool) option {
+	return NullifyInterfaceElem{Value: value}
+}
+
+// WithNilAsZero returns the NilAsZero option
+func WithNilAsZero(value bool) option {
+	return NilAsZero{Value: value}
+}
+
+// WithProtobuf returns the Protobuf option
+func WithProtobuf(value bool) option {
+	return Protobuf{Value: value}
+}
+
+// WithOnRecursion returns the OnRecursion option
+func WithOnRecursion(value Recursion) option {
+	return OnRecursion{Value: value}
+}
+
+// WithEncodeBytes returns the EncodeBytes option
+func WithEncodeBytes(value ByteEncoding) option {
+	return EncodeBytes{Value: value}
+}
+
+// WithMatchFields returns the MatchFields option
+func WithMatchFields(value Matching) option {
+	return MatchFields{Value: value}
+}
+
+// WithBareContainers returns the BareContainers option
+func WithBareContainers(value bool) option {
+	return BareContainers{Value: value}
+}
+
+// WithNullifyStructFields returns the NullifyStructFields option
+func WithNullifyStructFields(value bool) option {
+	return NullifyStructFields{Value: value}
+}
+
+// WithValueResult returns the ValueResult option
+func WithValueResult(value bool) option {
+	return ValueResult{Value: value}
+}
+
+// WithStripTags returns the StripTags option
+func WithStripTags(keys ...string) option {
+	return StripTags{Value: keys}
+}
+
+// WithRemapTag returns the RemapTag option
+func WithRemapTag(key string, fn func(value string) string) option {
+	return RemapTag{Key: key, Value: fn}
+}
+
+// WithTransform returns the Transform option
+func WithTransform(t Transformer) option {
+	return Transform{Value: t}
+}
@@ -0,0 +1,55 @@
+package nullify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	tests := map[string]struct {
+		Option   option
+		Expected option
+	}{
+		"WithBytesAsString":        {Option: WithBytesAsString(true), Expected: BytesAsString{Value: true}},
+		"WithNullifyArrayElem":     {Option: WithNullifyArrayElem(false), Expected: NullifyArrayElem{Value: false}},
+		"WithNullifySliceElem":     {Option: WithNullifySliceElem(false), Expected: NullifySliceElem{Value: false}},
+		"WithNullifyMapElem":       {Option: WithNullifyMapElem(false), Expected: NullifyMapElem{Value: false}},
+		"WithNullifyMapKey":        {Option: WithNullifyMapKey(false), Expected: NullifyMapKey{Value: false}},
+		"WithNullifyMarshalJson":   {Option: WithNullifyMarshalJson(true), Expected: NullifyMarshalJson{Value: true}},
+		"WithNullifyUnmarshalJson": {Option: WithNullifyUnmarshalJson(true), Expected: NullifyUnmarshalJson{Value: true}},
+		"WithNullifySqlScanner":    {Option: WithNullifySqlScanner(false), Expected: NullifySqlScanner{Value: false}},
+		"WithKeepNamedBytes":       {Option: WithKeepNamedBytes(true), Expected: KeepNamedBytes{Value: true}},
+		"WithOmitEmpty":            {Option: WithOmitEmpty(true), Expected: OmitEmpty{Value: true}},
+		"WithOmitZero":             {Option: WithOmitZero(true), Expected: OmitZero{Value: true}},
+		"WithValidateRequired":     {Option: WithValidateRequired(true), Expected: ValidateRequired{Value: true}},
+		"WithOmitNil":              {Option: WithOmitNil(true), Expected: OmitNil{Value: true}},
+		"WithFlattenEmbedded":      {Option: WithFlattenEmbedded(true), Expected: FlattenEmbedded{Value: true}},
+		"WithSafeMapKeys":          {Option: WithSafeMapKeys(true), Expected: SafeMapKeys{Value: true}},
+		"WithSkipUnserializable":   {Option: WithSkipUnserializable(true), Expected: SkipUnserializable{Value: true}},
+		"WithNullifyInterfaceElem": {Option: WithNullifyInterfaceElem(true), Expected: NullifyInterfaceElem{Value: true}},
+		"WithNilAsZero":            {Option: WithNilAsZero(true), Expected: NilAsZero{Value: true}},
+		"WithProtobuf":             {Option: WithProtobuf(true), Expected: Protobuf{Value: true}},
+		"WithOnRecursion":          {Option: WithOnRecursion(RecursionAny), Expected: OnRecursion{Value: RecursionAny}},
+		"WithEncodeBytes":          {Option: WithEncodeBytes(ByteEncodingHex), Expected: EncodeBytes{Value: ByteEncodingHex}},
+		"WithMatchFields":          {Option: WithMatchFields(MatchLoose), Expected: MatchFields{Value: MatchLoose}},
+		"WithStripTags":            {Option: WithStripTags("db", "gorm"), Expected: StripTags{Value: []string{"db", "gorm"}}},
+	}
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testData.Expected, testData.Option)
+			assert.Equal(t, ResolveOptions(testData.Expected), ResolveOptions(testData.Option))
+		})
+	}
+}
+
+func TestWithRemapTag(t *testing.T) {
+	// Act
+	cfg := newConfig(WithRemapTag("json", strings.ToUpper))
+
+	// Assert
+	assert.Len(t, cfg.tagRemaps, 1)
+	assert.Equal(t, "json", cfg.tagRemaps[0].Key)
+	assert.Equal(t, "NAME", cfg.tagRemaps[0].Value("name"))
+}